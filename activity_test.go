@@ -0,0 +1,68 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityFilter(t *testing.T) {
+	require.Equal(t, Filter{}, ActivityFilter{}.Filter())
+	require.Equal(t, Filter{
+		"collection": {OP_eq: "articles"},
+		"user":       {OP_eq: "u1"},
+	}, ActivityFilter{Collection: "articles", User: "u1"}.Filter())
+}
+
+func TestActivityTail(t *testing.T) {
+	var mu sync.Mutex
+	var log []map[string]any
+	for id := 1; id <= activityPageSize+1; id++ {
+		log = append(log, map[string]any{"id": id, "action": "update", "collection": "articles"})
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/activity", r.URL.Path)
+		require.Equal(t, "id", r.URL.Query().Get("sort"))
+		var filter struct {
+			Action map[string]string `json:"action"`
+			ID     map[string]int    `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter))
+		require.Equal(t, map[string]string{"_eq": "update"}, filter.Action)
+		mu.Lock()
+		defer mu.Unlock()
+		page := []map[string]any{}
+		for _, a := range log {
+			if a["id"].(int) > filter.ID["_gt"] && len(page) < activityPageSize {
+				page = append(page, a)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"data": page})
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	activities, errc := client.Activity().Tail(ctx, 1, ActivityFilter{Action: ActivityUpdate}, 10*time.Millisecond)
+	for id := 2; id <= activityPageSize+1; id++ {
+		require.Equal(t, id, (<-activities).ID)
+	}
+	mu.Lock()
+	log = append(log, map[string]any{"id": activityPageSize + 2, "action": "update"})
+	mu.Unlock()
+	require.Equal(t, activityPageSize+2, (<-activities).ID)
+
+	cancel()
+	for range activities {
+	}
+	require.ErrorIs(t, <-errc, context.Canceled)
+}
@@ -0,0 +1,135 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type AggregateFunc string
+
+const (
+	AggCount         AggregateFunc = "count"
+	AggCountDistinct AggregateFunc = "countDistinct"
+	AggCountAll      AggregateFunc = "countAll"
+	AggSum           AggregateFunc = "sum"
+	AggSumDistinct   AggregateFunc = "sumDistinct"
+	AggAvg           AggregateFunc = "avg"
+	AggAvgDistinct   AggregateFunc = "avgDistinct"
+	AggMin           AggregateFunc = "min"
+	AggMax           AggregateFunc = "max"
+)
+
+var aggregateFuncs = map[AggregateFunc]bool{
+	AggCount: true, AggCountDistinct: true, AggCountAll: true,
+	AggSum: true, AggSumDistinct: true, AggAvg: true, AggAvgDistinct: true,
+	AggMin: true, AggMax: true,
+}
+
+// Aggregate maps aggregate functions to the fields they are applied to, "*"
+// for counting items, e.g. Aggregate{AggCount: {"*"}, AggSum: {"price"}}.
+type Aggregate map[AggregateFunc]Fields
+
+// buildAggregate sets the aggregate[fn] and groupBy parameters of d.
+func (d *DirectusQuery) buildAggregate(v url.Values) {
+	for fn, fields := range d.Aggregate {
+		v.Set("aggregate["+string(fn)+"]", strings.Join(fields, ","))
+	}
+	if len(d.GroupBy) > 0 {
+		v.Set("groupBy", strings.Join(d.GroupBy, ","))
+	}
+}
+
+// parseAggregate reads the aggregate[fn] and groupBy parameters of q into d.
+func parseAggregate(q url.Values, d *DirectusQuery) error {
+	for k, values := range q {
+		if !strings.HasPrefix(k, "aggregate[") || !strings.HasSuffix(k, "]") {
+			continue
+		}
+		fn := AggregateFunc(k[len("aggregate[") : len(k)-1])
+		if !aggregateFuncs[fn] {
+			return errors.New("invalid aggregate function: " + strconv.Quote(string(fn)))
+		}
+		fields := strings.Split(values[0], ",")
+		if err := validateFieldList("aggregate", fields, false); err != nil {
+			return err
+		}
+		if d.Aggregate == nil {
+			d.Aggregate = Aggregate{}
+		}
+		d.Aggregate[fn] = fields
+	}
+	groupBy := q["groupBy"]
+	if len(groupBy) == 0 {
+		groupBy = q["groupBy[]"]
+	}
+	for _, g := range groupBy {
+		d.GroupBy = append(d.GroupBy, strings.Split(g, ",")...)
+	}
+	return validateFieldList("groupBy", d.GroupBy, false)
+}
+
+// AggregateRow is a row of an aggregate query: the values of the GroupBy
+// fields, and the results of the aggregate functions by field, "*" for
+// counts of items.
+type AggregateRow struct {
+	Group  map[string]any
+	Values map[AggregateFunc]map[string]any
+}
+
+// Value returns the result of fn on field, nil if missing.
+func (r AggregateRow) Value(fn AggregateFunc, field string) any {
+	return r.Values[fn][field]
+}
+
+// Float returns the result of fn on field as a number. Directus returns
+// results of some databases, e.g. PostgreSQL counts, as strings; they are
+// parsed. It is 0 for missing and non-numeric results such as the max of a
+// date.
+func (r AggregateRow) Float(fn AggregateFunc, field string) float64 {
+	switch v := r.Value(fn, field).(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+// Aggregate runs an aggregate query, query.Aggregate and optionally
+// query.GroupBy set, against collection.
+func (d *DirectusClient) Aggregate(ctx context.Context, collection string, query DirectusQuery) ([]AggregateRow, error) {
+	if len(query.Aggregate) == 0 {
+		return nil, errors.New("directus: aggregate query without aggregate functions")
+	}
+	resp, err := d.QueryContext(ctx, "GET", collection, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var data []map[string]any
+	if err := readItems(resp, &data, nil); err != nil {
+		return nil, err
+	}
+	rows := make([]AggregateRow, 0, len(data))
+	for _, item := range data {
+		row := AggregateRow{Group: map[string]any{}, Values: map[AggregateFunc]map[string]any{}}
+		for k, v := range item {
+			fn := AggregateFunc(k)
+			if _, ok := query.Aggregate[fn]; !ok {
+				row.Group[k] = v
+				continue
+			}
+			if byField, ok := v.(map[string]any); ok {
+				row.Values[fn] = byField
+			} else {
+				// counts of "*" are not keyed by field
+				row.Values[fn] = map[string]any{"*": v}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,54 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[
+			{"status":"published","count":"2","sum":{"views":30},"max":{"date_published":"2022-06-01"}},
+			{"status":"draft","count":"1","sum":{"views":null},"max":{"date_published":null}}
+		]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	q := DirectusQuery{
+		Filter:    Filter{"id": {OP_nnull: true}},
+		Aggregate: Aggregate{AggCount: {"*"}, AggSum: {"views"}, AggMax: {"date_published"}},
+		GroupBy:   Fields{"status"},
+	}
+	rows, err := client.Aggregate(context.Background(), "articles", q)
+	require.NoError(t, err)
+	require.Equal(t, "*", query.Get("aggregate[count]"))
+	require.Equal(t, "views", query.Get("aggregate[sum]"))
+	require.Equal(t, "status", query.Get("groupBy"))
+
+	require.Len(t, rows, 2)
+	require.Equal(t, map[string]any{"status": "published"}, rows[0].Group)
+	require.Equal(t, float64(2), rows[0].Float(AggCount, "*"))
+	require.Equal(t, float64(30), rows[0].Float(AggSum, "views"))
+	require.Equal(t, "2022-06-01", rows[0].Value(AggMax, "date_published"))
+	require.Equal(t, float64(0), rows[1].Float(AggSum, "views"))
+
+	parsed, err := ParseQuery(query)
+	require.NoError(t, err)
+	require.Equal(t, q.Aggregate, parsed.Aggregate)
+	require.Equal(t, q.GroupBy, parsed.GroupBy)
+
+	_, err = ParseQuery(url.Values{"filter": {`{}`}, "aggregate[median]": {"views"}})
+	require.Error(t, err)
+	_, err = client.Aggregate(context.Background(), "articles", DirectusQuery{Filter: Filter{}})
+	require.Error(t, err)
+}
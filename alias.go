@@ -0,0 +1,42 @@
+package directus_client
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Alias maps alias names to the fields they stand for, e.g.
+// Alias{"translated_title": "translations.title"}. An alias can be requested
+// in Fields like a field, and lets a relational field be queried twice with
+// different filters through deep.
+type Alias map[string]string
+
+// buildAlias sets the alias[name] parameters of d.
+func (d *DirectusQuery) buildAlias(v url.Values) {
+	for name, field := range d.Alias {
+		v.Set("alias["+name+"]", field)
+	}
+}
+
+// parseAlias reads the alias[name] parameters of q into d.
+func parseAlias(q url.Values, d *DirectusQuery) error {
+	for k, values := range q {
+		if !strings.HasPrefix(k, "alias[") || !strings.HasSuffix(k, "]") {
+			continue
+		}
+		name := k[len("alias[") : len(k)-1]
+		if !validFieldName(name) || strings.Contains(name, ".") {
+			return errors.New("invalid alias: " + strconv.Quote(name))
+		}
+		if !validFieldName(values[0]) {
+			return errors.New("invalid alias field: " + strconv.Quote(values[0]))
+		}
+		if d.Alias == nil {
+			d.Alias = Alias{}
+		}
+		d.Alias[name] = values[0]
+	}
+	return nil
+}
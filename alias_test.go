@@ -0,0 +1,34 @@
+package directus_client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlias(t *testing.T) {
+	q := DirectusQuery{
+		Fields: Fields{"id", "translated_title"},
+		Filter: Filter{"id": {OP_eq: 1}},
+		Alias:  Alias{"translated_title": "translations.title"},
+	}
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "translations.title", v.Get("alias[translated_title]"))
+	require.Contains(t, v.Encode(), "alias%5Btranslated_title%5D=translations.title")
+
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, q.Alias, parsed.Alias)
+
+	for _, bad := range []url.Values{
+		{"alias[a.b]": {"title"}},
+		{"alias[]": {"title"}},
+		{"alias[t]": {"title;drop"}},
+	} {
+		bad.Set("filter", "{}")
+		_, err := ParseQuery(bad)
+		require.Error(t, err)
+	}
+}
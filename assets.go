@@ -0,0 +1,124 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AssetFit is how a transformed image fills Width and Height.
+type AssetFit string
+
+const (
+	FitCover   AssetFit = "cover"
+	FitContain AssetFit = "contain"
+	FitInside  AssetFit = "inside"
+	FitOutside AssetFit = "outside"
+)
+
+// AssetFormat is the image format of a transformed asset.
+type AssetFormat string
+
+const (
+	FormatAuto AssetFormat = "auto"
+	FormatJPG  AssetFormat = "jpg"
+	FormatPNG  AssetFormat = "png"
+	FormatWebP AssetFormat = "webp"
+	FormatTIFF AssetFormat = "tiff"
+	FormatAVIF AssetFormat = "avif"
+)
+
+// AssetOptions transforms an image asset. Key selects a transformation
+// preset of the project settings and excludes the other transformations;
+// zero fields are left out. Download asks for an attachment.
+type AssetOptions struct {
+	Key                string
+	Width              int
+	Height             int
+	Fit                AssetFit
+	Format             AssetFormat
+	Quality            int
+	WithoutEnlargement bool
+	Download           bool
+}
+
+func (o AssetOptions) values() (url.Values, error) {
+	v := url.Values{}
+	if o.Key != "" {
+		if o.Width != 0 || o.Height != 0 || o.Fit != "" || o.Format != "" || o.Quality != 0 || o.WithoutEnlargement {
+			return nil, errors.New("directus: asset key excludes other transformations")
+		}
+		v.Set("key", o.Key)
+	}
+	if o.Width < 0 || o.Height < 0 {
+		return nil, errors.New("directus: asset width and height must not be negative")
+	}
+	if o.Width > 0 {
+		v.Set("width", strconv.Itoa(o.Width))
+	}
+	if o.Height > 0 {
+		v.Set("height", strconv.Itoa(o.Height))
+	}
+	switch o.Fit {
+	case "":
+	case FitCover, FitContain, FitInside, FitOutside:
+		v.Set("fit", string(o.Fit))
+	default:
+		return nil, errors.New("directus: invalid asset fit " + strconv.Quote(string(o.Fit)))
+	}
+	switch o.Format {
+	case "":
+	case FormatAuto, FormatJPG, FormatPNG, FormatWebP, FormatTIFF, FormatAVIF:
+		v.Set("format", string(o.Format))
+	default:
+		return nil, errors.New("directus: invalid asset format " + strconv.Quote(string(o.Format)))
+	}
+	if o.Quality != 0 {
+		if o.Quality < 1 || o.Quality > 100 {
+			return nil, errors.New("directus: asset quality must be between 1 and 100")
+		}
+		v.Set("quality", strconv.Itoa(o.Quality))
+	}
+	if o.WithoutEnlargement {
+		v.Set("withoutEnlargement", "true")
+	}
+	if o.Download {
+		v.Set("download", "")
+	}
+	return v, nil
+}
+
+// GetAsset returns the content of the file fileID, transformed by opts, and
+// its content type. The caller must close the content.
+func (d *DirectusClient) GetAsset(ctx context.Context, fileID string, opts AssetOptions) (io.ReadCloser, string, error) {
+	key, err := formatKey(fileID)
+	if err != nil {
+		return nil, "", err
+	}
+	v, err := opts.values()
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := d.getAsset(ctx, key, v)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// getAsset requests the asset with key and transformation parameters v and
+// returns the response if successful.
+func (d *DirectusClient) getAsset(ctx context.Context, key string, v url.Values) (*http.Response, error) {
+	resp, err := d.callSystem(ctx, "GET", "/assets/"+key, v, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError(resp, resp.Body)
+	}
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package directus_client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAsset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/assets/f1" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":[{"message":"forbidden","extensions":{"code":"FORBIDDEN"}}]}`))
+			return
+		}
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "image/webp")
+		w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	body, contentType, err := client.GetAsset(ctx, "f1", AssetOptions{Width: 200, Height: 100, Fit: FitCover, Format: FormatWebP, Quality: 80, WithoutEnlargement: true})
+	require.NoError(t, err)
+	b, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "image/webp", contentType)
+	require.Equal(t, "fit=cover&format=webp&height=100&quality=80&width=200&withoutEnlargement=true", string(b))
+
+	body, _, err = client.GetAsset(ctx, "f1", AssetOptions{Key: "thumbnail", Download: true})
+	require.NoError(t, err)
+	b, _ = io.ReadAll(body)
+	body.Close()
+	require.Equal(t, "download=&key=thumbnail", string(b))
+
+	_, _, err = client.GetAsset(ctx, "f2", AssetOptions{})
+	require.ErrorIs(t, err, ErrForbidden)
+
+	for _, bad := range []AssetOptions{
+		{Key: "thumbnail", Width: 10},
+		{Width: -1},
+		{Fit: "stretch"},
+		{Format: "gif"},
+		{Quality: 101},
+	} {
+		_, _, err := client.GetAsset(ctx, "f1", bad)
+		require.Error(t, err, bad)
+	}
+}
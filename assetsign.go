@@ -0,0 +1,151 @@
+package directus_client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AssetURL returns the Directus URL of the file fileID transformed by opts,
+// for embedding in pages. accessToken, if not empty, is added as the
+// access_token parameter, so it should be a token of a user that may only
+// read files, never the token of the client. See AssetSigner for URLs that
+// reveal no token at all.
+func (d *DirectusClient) AssetURL(fileID string, opts AssetOptions, accessToken string) (string, error) {
+	key, err := formatKey(fileID)
+	if err != nil {
+		return "", err
+	}
+	v, err := opts.values()
+	if err != nil {
+		return "", err
+	}
+	if accessToken != "" {
+		v.Set("access_token", accessToken)
+	}
+	u := &url.URL{Scheme: d.baseURL.Scheme, Host: d.baseURL.Host, Path: "/assets/" + key, RawQuery: v.Encode()}
+	return u.String(), nil
+}
+
+// Errors of AssetSigner.Verify.
+var (
+	ErrInvalidSignature = errors.New("directus: invalid asset signature")
+	ErrSignatureExpired = errors.New("directus: asset signature expired")
+)
+
+// AssetSigner signs short-lived asset URLs pointing at a route of your own
+// service, served by DirectusClient.SignedAssets, which fetches the assets
+// with the token of the client after checking the signature. Frontends can
+// embed such URLs without learning any token, and can't change the file or
+// its transformation.
+type AssetSigner struct {
+	baseURL *url.URL
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewAssetSigner returns a signer of URLs below baseURL, e.g.
+// "https://app.example.com/assets", with the HMAC key secret, which should
+// be at least 32 random bytes.
+func NewAssetSigner(baseURL string, secret []byte) (*AssetSigner, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("directus: asset signer requires a secret")
+	}
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return &AssetSigner{baseURL: u, secret: secret, now: time.Now}, nil
+}
+
+// URL returns the signed URL of the file fileID transformed by opts, valid
+// for ttl.
+func (s *AssetSigner) URL(fileID string, opts AssetOptions, ttl time.Duration) (string, error) {
+	key, err := formatKey(fileID)
+	if err != nil {
+		return "", err
+	}
+	v, err := opts.values()
+	if err != nil {
+		return "", err
+	}
+	v.Set("expires", strconv.FormatInt(s.now().Add(ttl).Unix(), 10))
+	v.Set("signature", s.sign(key, v))
+	u := *s.baseURL
+	u.Path += "/" + key
+	u.RawQuery = v.Encode()
+	return u.String(), nil
+}
+
+// Verify checks the signature of a request for a signed URL and returns the
+// file id and the transformation parameters to request from Directus.
+func (s *AssetSigner) Verify(r *http.Request) (string, url.Values, error) {
+	key := path.Base(r.URL.Path)
+	v := r.URL.Query()
+	signature := v.Get("signature")
+	v.Del("signature")
+	if signature == "" || !hmac.Equal([]byte(signature), []byte(s.sign(key, v))) {
+		return "", nil, ErrInvalidSignature
+	}
+	expires, err := strconv.ParseInt(v.Get("expires"), 10, 64)
+	if err != nil {
+		return "", nil, ErrInvalidSignature
+	}
+	if s.now().Unix() > expires {
+		return "", nil, ErrSignatureExpired
+	}
+	v.Del("expires")
+	return key, v, nil
+}
+
+// sign returns the signature of the file key with parameters v.
+func (s *AssetSigner) sign(key string, v url.Values) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "?" + v.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignedAssets serves the assets of URLs signed by s, with their content
+// type and caching headers, and 403 Forbidden for invalid or expired
+// signatures. Mount it at the path of the base URL of s.
+func (d *DirectusClient) SignedAssets(s *AssetSigner) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" && r.Method != "HEAD" {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		key, v, err := s.Verify(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		resp, err := d.getAsset(r.Context(), key, v)
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				http.Error(w, http.StatusText(apiErr.StatusCode), apiErr.StatusCode)
+			} else {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+			}
+			return
+		}
+		defer resp.Body.Close()
+		for _, h := range []string{"Content-Type", "Content-Disposition", "Cache-Control", "ETag", "Last-Modified"} {
+			if value := resp.Header.Get(h); value != "" {
+				w.Header().Set(h, value)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == "GET" {
+			io.Copy(w, resp.Body)
+		}
+	})
+}
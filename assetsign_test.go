@@ -0,0 +1,88 @@
+package directus_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetURL(t *testing.T) {
+	client, err := NewDirectusClient("https://cms.example.com", "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	u, err := client.AssetURL("f1", AssetOptions{Key: "thumbnail"}, "read-only")
+	require.NoError(t, err)
+	require.Equal(t, "https://cms.example.com/assets/f1?access_token=read-only&key=thumbnail", u)
+	u, err = client.AssetURL("f1", AssetOptions{Width: 64}, "")
+	require.NoError(t, err)
+	require.Equal(t, "https://cms.example.com/assets/f1?width=64", u)
+	_, err = client.AssetURL("f1", AssetOptions{Fit: "stretch"}, "")
+	require.Error(t, err)
+}
+
+func TestSignedAssets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Path != "/assets/f1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	_, err = NewAssetSigner("https://app.example.com/img", nil)
+	require.Error(t, err)
+	signer, err := NewAssetSigner("https://app.example.com/img/", []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	signer.now = func() time.Time { return now }
+	handler := client.SignedAssets(signer)
+
+	signed, err := signer.URL("f1", AssetOptions{Width: 300, Format: FormatWebP}, time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, "app.example.com", u.Host)
+	require.Equal(t, "/img/f1", u.Path)
+	require.Equal(t, "1700000060", u.Query().Get("expires"))
+	require.NotContains(t, signed, "token")
+
+	serve := func(rawURL string) *httptest.ResponseRecorder {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", u.RequestURI(), nil))
+		return w
+	}
+	w := serve(signed)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	require.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+	require.Equal(t, "format=webp&width=300", w.Body.String())
+
+	// tampered transformations and files fail
+	tampered := u.Query()
+	tampered.Set("width", "3000")
+	require.Equal(t, http.StatusForbidden, serve("/img/f1?"+tampered.Encode()).Code)
+	require.Equal(t, http.StatusForbidden, serve("/img/f2?"+u.RawQuery).Code)
+	require.Equal(t, http.StatusForbidden, serve("/img/f1").Code)
+
+	now = now.Add(2 * time.Minute)
+	w = serve(signed)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), ErrSignatureExpired.Error())
+
+	// Directus errors are passed on
+	now = time.Unix(1700000000, 0)
+	signed, err = signer.URL("f3", AssetOptions{}, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, serve(signed).Code)
+}
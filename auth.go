@@ -0,0 +1,264 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLoggedOut is returned for requests of a client after Logout.
+var ErrLoggedOut = errors.New("directus: logged out")
+
+// tokens are refreshed this long before they expire, so requests in flight
+// don't race the expiry
+const refreshMargin = 30 * time.Second
+
+// TokenProvider supplies the access token of every request, so token rotation,
+// e.g. of secrets mounted from Vault or Kubernetes, is handled in one place.
+// Token is called concurrently and should be cheap when the token is
+// unchanged.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a fixed token, as used by NewDirectusClient.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// clientTokenProvider is implemented by providers talking to Directus
+// themselves, through the client using them.
+type clientTokenProvider interface {
+	bind(d *DirectusClient)
+}
+
+// AuthTokens are the tokens Directus issues on login.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	// Expires is when AccessToken expires; zero for static tokens.
+	Expires time.Time
+}
+
+type authResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// milliseconds
+	Expires int64 `json:"expires"`
+}
+
+func (r authResponse) tokens() AuthTokens {
+	return AuthTokens{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		Expires:      time.Now().Add(time.Duration(r.Expires) * time.Millisecond),
+	}
+}
+
+// LoginToken logs in with email and password via /auth/login on first use.
+// The access token is refreshed once it is about to expire, concurrent
+// requests waiting for the refresh, and the login repeated when the refresh
+// token expired too. A LoginToken belongs to the first client using it.
+type LoginToken struct {
+	email    string
+	password string
+
+	mu        sync.Mutex
+	d         *DirectusClient
+	tokens    AuthTokens
+	loggedOut bool
+}
+
+var _ TokenProvider = (*LoginToken)(nil)
+
+func NewLoginToken(email string, password string) *LoginToken {
+	return &LoginToken{email: email, password: password}
+}
+
+func (l *LoginToken) bind(d *DirectusClient) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.d == nil {
+		l.d = d
+	}
+}
+
+func (l *LoginToken) Token(ctx context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loggedOut {
+		return "", ErrLoggedOut
+	}
+	if l.d == nil {
+		return "", errors.New("directus: LoginToken is not used by a client")
+	}
+	if l.tokens.AccessToken != "" && time.Until(l.tokens.Expires) > refreshMargin {
+		return l.tokens.AccessToken, nil
+	}
+	if l.tokens.RefreshToken != "" {
+		tokens, err := l.d.authenticate(ctx, "/auth/refresh", map[string]string{
+			"refresh_token": l.tokens.RefreshToken,
+			"mode":          "json",
+		})
+		if err == nil {
+			l.d.log.Debug("refreshed directus access token", "expires", tokens.Expires)
+			l.tokens = tokens
+			return tokens.AccessToken, nil
+		}
+		if l.password == "" {
+			return "", err
+		}
+		l.d.log.Warn("failed to refresh directus access token, logging in again", "error", err)
+	}
+	tokens, err := l.d.authenticate(ctx, "/auth/login", map[string]string{
+		"email":    l.email,
+		"password": l.password,
+		"mode":     "json",
+	})
+	if err != nil {
+		return "", err
+	}
+	l.tokens = tokens
+	return tokens.AccessToken, nil
+}
+
+// Tokens returns the current tokens, zero before the first request.
+func (l *LoginToken) Tokens() AuthTokens {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tokens
+}
+
+// NewDirectusClientWithTokenProvider creates a client asking tokens for the
+// token of every request.
+func NewDirectusClientWithTokenProvider(baseURL string, tokens TokenProvider, cache QueryCache, opts ...ClientOption) (*DirectusClient, error) {
+	if tokens == nil {
+		return nil, errors.New("token provider is required")
+	}
+	d, err := newDirectusClient(baseURL, cache, opts...)
+	if err != nil {
+		return nil, err
+	}
+	d.tokens = tokens
+	if p, ok := tokens.(clientTokenProvider); ok {
+		p.bind(d)
+	}
+	return d, nil
+}
+
+// NewDirectusClientWithLogin authenticates with email and password instead of
+// a static token, see LoginToken, so no long lived admin token has to be kept
+// in the configuration. It logs in right away to report wrong credentials.
+func NewDirectusClientWithLogin(ctx context.Context, baseURL string, email string, password string, cache QueryCache, opts ...ClientOption) (*DirectusClient, error) {
+	if email == "" || password == "" {
+		return nil, errors.New("email and password are required")
+	}
+	d, err := NewDirectusClientWithTokenProvider(baseURL, NewLoginToken(email, password), cache, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.tokens.Token(ctx); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// authenticate posts body to an /auth endpoint issuing tokens.
+func (d *DirectusClient) authenticate(ctx context.Context, path string, body any) (AuthTokens, error) {
+	b, err := currentCodec().Marshal(body)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	resp, err := d.callPublic(ctx, "POST", path, nil, bytes.NewReader(b))
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	var r authResponse
+	if err := readData(resp, &r); err != nil {
+		return AuthTokens{}, err
+	}
+	if r.AccessToken == "" {
+		return AuthTokens{}, errors.New("directus: no access token in response of " + path)
+	}
+	return r.tokens(), nil
+}
+
+// Tokens returns the current tokens of d if it uses a StaticToken or a
+// LoginToken.
+func (d *DirectusClient) Tokens() AuthTokens {
+	switch t := d.tokens.(type) {
+	case StaticToken:
+		return AuthTokens{AccessToken: string(t)}
+	case *LoginToken:
+		return t.Tokens()
+	}
+	return AuthTokens{}
+}
+
+// accessToken returns the token to send with a request.
+func (d *DirectusClient) accessToken(ctx context.Context) (string, error) {
+	return d.tokens.Token(ctx)
+}
+
+// Logout revokes the refresh token of a client using a LoginToken via
+// /auth/logout and clears its tokens. Requests fail with ErrLoggedOut
+// afterwards.
+func (d *DirectusClient) Logout(ctx context.Context) error {
+	l, ok := d.tokens.(*LoginToken)
+	if !ok {
+		return errors.New("directus: client has no session to log out")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loggedOut {
+		return ErrLoggedOut
+	}
+	l.loggedOut = true
+	refreshToken := l.tokens.RefreshToken
+	l.tokens = AuthTokens{}
+	if refreshToken == "" {
+		return nil
+	}
+	return d.postPublic(ctx, "/auth/logout", map[string]string{
+		"refresh_token": refreshToken,
+		"mode":          "json",
+	})
+}
+
+// postPublic posts body as JSON to an /auth endpoint returning no data.
+func (d *DirectusClient) postPublic(ctx context.Context, path string, body any) error {
+	b, err := currentCodec().Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := d.callPublic(ctx, "POST", path, nil, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	return readData(resp, nil)
+}
+
+// RequestPasswordReset makes Directus email a password reset link to the user
+// with email, if any. resetURL, which must be allowed by
+// PASSWORD_RESET_URL_ALLOW_LIST, replaces the link to the Data Studio; the
+// token is appended as query parameter "token".
+func (d *DirectusClient) RequestPasswordReset(ctx context.Context, email string, resetURL string) error {
+	body := map[string]string{"email": email}
+	if resetURL != "" {
+		body["reset_url"] = resetURL
+	}
+	return d.postPublic(ctx, "/auth/password/request", body)
+}
+
+// ResetPassword sets the password of the user a reset token was issued for.
+func (d *DirectusClient) ResetPassword(ctx context.Context, token string, password string) error {
+	return d.postPublic(ctx, "/auth/password/reset", map[string]string{
+		"token":    token,
+		"password": password,
+	})
+}
@@ -0,0 +1,223 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// authServer issues access tokens a1, a2, ... expiring after expires ms and
+// serves items to the current one.
+type authServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	issued  int
+	expires int64
+	calls   []string
+}
+
+func newAuthServer(t *testing.T) *authServer {
+	s := &authServer{expires: 900000}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.calls = append(s.calls, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]string
+		switch r.URL.Path {
+		case "/auth/login":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, "json", body["mode"])
+			if body["email"] != "dev@dev.io" || body["password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"errors":[{"message":"Invalid user credentials."}]}`))
+				return
+			}
+			s.issue(w)
+		case "/auth/refresh":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["refresh_token"] != "r"+strconv.Itoa(s.issued) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"errors":[{"message":"Invalid user credentials."}]}`))
+				return
+			}
+			s.issue(w)
+		case "/auth/logout":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, "r"+strconv.Itoa(s.issued), body["refresh_token"])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			if r.Header.Get("Authorization") != "Bearer a"+strconv.Itoa(s.issued) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *authServer) issue(w http.ResponseWriter) {
+	s.issued++
+	n := strconv.Itoa(s.issued)
+	json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+		"access_token":  "a" + n,
+		"refresh_token": "r" + n,
+		"expires":       s.expires,
+	}})
+}
+
+func (s *authServer) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func TestLogin(t *testing.T) {
+	srv := newAuthServer(t)
+	srv.expires = 1000
+	ctx := context.Background()
+
+	_, err := NewDirectusClientWithLogin(ctx, srv.URL, "dev@dev.io", "wrong", NewNoopQueryCache())
+	require.EqualError(t, err, "directus: 401 Unauthorized: Invalid user credentials.")
+
+	client, err := NewDirectusClientWithLogin(ctx, srv.URL, "dev@dev.io", "secret", NewNoopQueryCache())
+	require.NoError(t, err)
+	tokens := client.Tokens()
+	require.Equal(t, "a1", tokens.AccessToken)
+	require.Equal(t, "r1", tokens.RefreshToken)
+	require.WithinDuration(t, time.Now().Add(time.Second), tokens.Expires, time.Second)
+
+	// the access token expires within the refresh margin
+	srv.mu.Lock()
+	srv.expires = 900000
+	srv.mu.Unlock()
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+	}
+	require.Equal(t, "a2", client.Tokens().AccessToken)
+	require.Equal(t, []string{"/auth/login", "/auth/login", "/auth/refresh", "/items/user", "/items/user"}, srv.Calls())
+}
+
+func TestLogout(t *testing.T) {
+	srv := newAuthServer(t)
+	ctx := context.Background()
+	client, err := NewDirectusClientWithLogin(ctx, srv.URL, "dev@dev.io", "secret", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	require.NoError(t, client.Logout(ctx))
+	require.Equal(t, AuthTokens{}, client.Tokens())
+	_, err = client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.ErrorIs(t, err, ErrLoggedOut)
+	require.ErrorIs(t, client.Logout(ctx), ErrLoggedOut)
+	require.Equal(t, []string{"/auth/login", "/auth/logout"}, srv.Calls())
+
+	static, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	require.Error(t, static.Logout(ctx))
+}
+
+type rotatingToken struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (r *rotatingToken) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.token, nil
+}
+
+func TestTokenProvider(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	tokens := &rotatingToken{token: "t1"}
+	client, err := NewDirectusClientWithTokenProvider(srv.URL, tokens, NewNoopQueryCache())
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	get := func() {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	get()
+	tokens.mu.Lock()
+	tokens.token = "t2"
+	tokens.mu.Unlock()
+	get()
+	require.Equal(t, []string{"Bearer t1", "Bearer t2"}, seen)
+	require.Equal(t, AuthTokens{}, client.Tokens())
+}
+
+func TestLoginTokenLogsInAgain(t *testing.T) {
+	srv := newAuthServer(t)
+	l := NewLoginToken("dev@dev.io", "secret")
+	_, err := l.Token(context.Background())
+	require.Error(t, err)
+	client, err := NewDirectusClientWithTokenProvider(srv.URL, l, NewNoopQueryCache())
+	require.NoError(t, err)
+
+	token, err := l.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "a1", token)
+
+	// an expired refresh token is rejected, so the client logs in again
+	l.mu.Lock()
+	l.tokens.Expires = time.Now()
+	l.tokens.RefreshToken = "expired"
+	l.mu.Unlock()
+	resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "a2", client.Tokens().AccessToken)
+	require.Equal(t, []string{"/auth/login", "/auth/refresh", "/auth/login", "/items/user"}, srv.Calls())
+}
+
+func TestPasswordReset(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/auth/password/request":
+			got = append(got, body["email"]+" "+body["reset_url"])
+		case "/auth/password/reset":
+			if body["token"] != "reset-token" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"errors":[{"message":"Invalid token."}]}`))
+				return
+			}
+			got = append(got, body["token"]+" "+body["password"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.RequestPasswordReset(ctx, "dev@dev.io", "https://app.enku.chat/reset"))
+	require.NoError(t, client.ResetPassword(ctx, "reset-token", "n3w"))
+	require.EqualError(t, client.ResetPassword(ctx, "stale", "n3w"), "directus: 403 Forbidden: Invalid token.")
+	require.Equal(t, []string{"dev@dev.io https://app.enku.chat/reset", "reset-token n3w"}, got)
+}
@@ -0,0 +1,9 @@
+// Package benchmarks measures the proxy path of directus_client against a
+// directustest fixture server, with and without the query cache:
+//
+//	go test ./benchmarks -run XXX -bench . -benchmem -items 10,1000 -concurrency 1,64
+//
+// -items sets the number of items per response and -concurrency the number of
+// goroutines per CPU. TestServe exposes the same setup for external load
+// generators such as k6.js.
+package benchmarks
@@ -1,4 +1,5 @@
-// k6 run --out csv=benchmark_result.csv benchmark.js
+// go test ./benchmarks -run TestServe -serve :9090 &
+// k6 run --out csv=benchmark_result.csv k6.js
 import http    from 'k6/http'
 import {check} from 'k6'
 
@@ -0,0 +1,155 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	directus_client "gitlab.enkuchat.com/backend/directus_client"
+	"gitlab.enkuchat.com/backend/directus_client/directustest"
+)
+
+var (
+	itemsFlag       = flag.String("items", "10,100,1000", "comma separated numbers of items per response")
+	concurrencyFlag = flag.String("concurrency", "1,16", "comma separated numbers of goroutines per CPU")
+	serveFlag       = flag.String("serve", "", "address TestServe exposes the proxy on, e.g. :9090")
+)
+
+// memoryCacheService stands in for redis, which hands out values as strings.
+type memoryCacheService struct {
+	mu sync.RWMutex
+	m  map[string]string
+}
+
+func (m *memoryCacheService) Get(key string) ([]byte, error) {
+	s, err := m.GetString(key)
+	return []byte(s), err
+}
+func (m *memoryCacheService) GetString(key string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m[key], nil
+}
+func (m *memoryCacheService) Set(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m[key] = string(value)
+	return nil
+}
+func (m *memoryCacheService) Del(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.m, key)
+	return nil
+}
+func (m *memoryCacheService) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m = make(map[string]string)
+	return nil
+}
+
+func payload(items int) []byte {
+	data := make([]map[string]any, items)
+	for i := range data {
+		data[i] = map[string]any{
+			"id":    i + 1,
+			"email": fmt.Sprintf("user%d@dev.io", i+1),
+			"bio":   strings.Repeat("lorem ipsum ", 8),
+		}
+	}
+	b, _ := json.Marshal(map[string]any{"data": data})
+	return b
+}
+
+func newProxy(tb testing.TB, items int, cached bool) (http.Handler, *directustest.Server) {
+	srv := directustest.NewServer(tb, directustest.Fixture{Collection: "user", Body: payload(items)})
+	cache := directus_client.NewNoopQueryCache()
+	if cached {
+		wes, err := directus_client.NewWebhookEventServer("127.0.0.1:0", "/webhook")
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tb.Cleanup(func() { wes.Shutdown() })
+		cache, err = directus_client.NewRefreshableQueryCache(&memoryCacheService{m: make(map[string]string)}, wes)
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+	client, err := directus_client.NewDirectusClient(srv.URL, directustest.Token, cache)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	router := chi.NewRouter()
+	router.Handle("/forward/*", client.Proxy(1))
+	return router, srv
+}
+
+func ints(tb testing.TB, s string) []int {
+	var l []int
+	for _, v := range strings.Split(s, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			tb.Fatalf("invalid flag value %q", s)
+		}
+		l = append(l, i)
+	}
+	return l
+}
+
+func BenchmarkProxy(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		for _, items := range ints(b, *itemsFlag) {
+			for _, concurrency := range ints(b, *concurrencyFlag) {
+				name := fmt.Sprintf("cached=%t/items=%d/concurrency=%d", cached, items, concurrency)
+				b.Run(name, func(b *testing.B) {
+					benchmarkProxy(b, items, cached, concurrency)
+				})
+			}
+		}
+	}
+}
+
+func benchmarkProxy(b *testing.B, items int, cached bool, concurrency int) {
+	handler, _ := newProxy(b, items, cached)
+	serve := func() {
+		r := httptest.NewRequest("GET", "/forward/items/user?limit=100", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			b.Errorf("unexpected status %d: %s", w.Code, w.Body)
+		}
+	}
+	// the first request populates the cache
+	serve()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload(items))))
+	b.SetParallelism(concurrency)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			serve()
+		}
+	})
+}
+
+// TestServe serves the cached proxy on -serve until interrupted, as the
+// target of k6.js. It is skipped unless -serve is set.
+func TestServe(t *testing.T) {
+	if *serveFlag == "" {
+		t.Skip("-serve not set")
+	}
+	handler, _ := newProxy(t, ints(t, *itemsFlag)[0], true)
+	t.Log("proxy listening on " + *serveFlag)
+	if err := http.ListenAndServe(*serveFlag, handler); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,98 @@
+package directus_client
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// buffers larger than this are left to the GC instead of pinning memory in
+// the pool after an unusually large response
+const maxPooledBufferSize = 4 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+var copyBufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 32<<10)
+		return &b
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBufferSize {
+		return
+	}
+	b.Reset()
+	bufferPool.Put(b)
+}
+
+// cachingBody hands the upstream body to the caller as it is read, teeing it
+// into a pooled buffer. Once the body has been read to EOF a copy of the
+// buffer is passed to onEOF, which may keep it, so the payload is read only
+// once and the cache fills as a side effect. A body that is closed early is
+// never cached.
+type cachingBody struct {
+	io.ReadCloser
+	buf   *bytes.Buffer
+	onEOF func([]byte)
+}
+
+func newCachingBody(body io.ReadCloser, onEOF func([]byte)) *cachingBody {
+	return &cachingBody{ReadCloser: body, buf: getBuffer(), onEOF: onEOF}
+}
+
+func (c *cachingBody) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if c.buf != nil {
+		c.buf.Write(p[:n])
+		if err == io.EOF {
+			// the buffer goes back to the pool, caches keep what they get
+			c.onEOF(append([]byte(nil), c.buf.Bytes()...))
+			c.release()
+		}
+	}
+	return n, err
+}
+
+// WriteTo lets io.Copy stream the body with a pooled buffer instead of
+// allocating one per response.
+func (c *cachingBody) WriteTo(w io.Writer) (int64, error) {
+	bp := copyBufferPool.Get().(*[]byte)
+	defer copyBufferPool.Put(bp)
+	var total int64
+	for {
+		n, err := c.Read(*bp)
+		if n > 0 {
+			written, werr := w.Write((*bp)[:n])
+			total += int64(written)
+			if werr != nil {
+				return total, werr
+			}
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+func (c *cachingBody) Close() error {
+	c.release()
+	return c.ReadCloser.Close()
+}
+
+func (c *cachingBody) release() {
+	if c.buf != nil {
+		putBuffer(c.buf)
+		c.buf = nil
+	}
+}
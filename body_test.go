@@ -0,0 +1,45 @@
+package directus_client
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingBody(t *testing.T) {
+	var cached []byte
+	body := newCachingBody(io.NopCloser(strings.NewReader(`{"data": []}`)), func(b []byte) {
+		cached = append([]byte(nil), b...)
+	})
+	out := new(bytes.Buffer)
+	_, err := io.Copy(out, body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, `{"data": []}`, out.String())
+	require.Equal(t, `{"data": []}`, string(cached))
+
+	// caches may keep the value after the buffer went back to the pool
+	var kept [][]byte
+	for _, payload := range []string{`{"data": [1]}`, `{"data": [2]}`} {
+		body := newCachingBody(io.NopCloser(strings.NewReader(payload)), func(b []byte) {
+			kept = append(kept, b)
+		})
+		_, err := io.Copy(io.Discard, body)
+		require.NoError(t, err)
+		require.NoError(t, body.Close())
+	}
+	require.Equal(t, `{"data": [1]}`, string(kept[0]))
+	require.Equal(t, `{"data": [2]}`, string(kept[1]))
+
+	cached = nil
+	body = newCachingBody(io.NopCloser(strings.NewReader(`{"data": []}`)), func(b []byte) {
+		cached = b
+	})
+	_, err = body.Read(make([]byte, 4))
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Nil(t, cached)
+}
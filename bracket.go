@@ -0,0 +1,233 @@
+package directus_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithBracketFilters sends the filter of item queries as bracket parameters,
+// e.g. filter[status][_eq]=published, instead of a single JSON parameter.
+// Such URLs survive CDNs and load balancers that mangle JSON in query strings
+// and stay readable in access logs. ParseQuery accepts both forms.
+func WithBracketFilters() ClientOption {
+	return func(d *DirectusClient) {
+		d.bracketFilters = true
+	}
+}
+
+// BuildBracketQuery is BuildQuery with the filter encoded as bracket
+// parameters, see WithBracketFilters.
+func (d *DirectusQuery) BuildBracketQuery() (url.Values, error) {
+	v, err := d.BuildQuery()
+	if err != nil {
+		return nil, err
+	}
+	if err := bracketFilter(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// bracketFilter replaces the JSON filter parameter of v with bracket
+// parameters. Filters with an empty list, e.g. {"id":{"_in":[]}} or
+// {"_and":[]}, have no bracket form and keep the JSON parameter.
+func bracketFilter(v url.Values) error {
+	filter := v.Get("filter")
+	if filter == "" {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(filter))
+	dec.UseNumber()
+	var obj any
+	if err := dec.Decode(&obj); err != nil {
+		return err
+	}
+	if hasEmptyList(obj) {
+		return nil
+	}
+	v.Del("filter")
+	encodeBrackets(v, "filter", obj)
+	return nil
+}
+
+// hasEmptyList reports whether obj contains an empty list, which
+// encodeBrackets would drop.
+func hasEmptyList(obj any) bool {
+	switch obj := obj.(type) {
+	case map[string]any:
+		for _, o := range obj {
+			if hasEmptyList(o) {
+				return true
+			}
+		}
+	case []any:
+		if len(obj) == 0 {
+			return true
+		}
+		for _, o := range obj {
+			if hasEmptyList(o) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func encodeBrackets(v url.Values, key string, obj any) {
+	switch obj := obj.(type) {
+	case map[string]any:
+		if len(obj) == 0 {
+			v.Set(key, "{}")
+		}
+		for k, o := range obj {
+			encodeBrackets(v, key+"["+k+"]", o)
+		}
+	case []any:
+		for i, o := range obj {
+			encodeBrackets(v, key+"["+strconv.Itoa(i)+"]", o)
+		}
+	case string:
+		v.Set(key, obj)
+	case json.Number:
+		v.Set(key, obj.String())
+	case bool:
+		v.Set(key, strconv.FormatBool(obj))
+	case nil:
+		v.Set(key, "null")
+	}
+}
+
+// hasBracketFilter reports whether q has filter[...] parameters.
+func hasBracketFilter(q url.Values) bool {
+	for k := range q {
+		if strings.HasPrefix(k, "filter[") {
+			return true
+		}
+	}
+	return false
+}
+
+// unbracketFilter reassembles the filter[...] parameters of q as the JSON
+// filter, enforcing the limits of ParseQuery.
+func unbracketFilter(q url.Values) ([]byte, error) {
+	keys := make([]string, 0, len(q))
+	size := 0
+	for k, vs := range q {
+		if !strings.HasPrefix(k, "filter[") {
+			continue
+		}
+		keys = append(keys, k)
+		for _, s := range vs {
+			size += len(k) + len(s)
+		}
+	}
+	if size > QUERY_MAX_FILTER_SIZE {
+		return nil, errors.New("filter is too large")
+	}
+	sort.Strings(keys)
+
+	root := map[string]any{}
+	for _, k := range keys {
+		path, err := bracketPath(k[len("filter"):])
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > QUERY_MAX_FILTER_DEPTH {
+			return nil, errors.New("filter is nested too deeply")
+		}
+		var value any
+		if vs := q[k]; len(vs) == 1 {
+			value = vs[0]
+		} else {
+			list := make([]any, len(vs))
+			for i, s := range vs {
+				list[i] = s
+			}
+			value = list
+		}
+		if err := setBracket(root, path, value); err != nil {
+			return nil, errors.New("invalid filter parameter " + strconv.Quote(k) + ": " + err.Error())
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(bracketLists(root)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// bracketPath splits "[a][b][0]" into its segments.
+func bracketPath(s string) ([]string, error) {
+	var path []string
+	for s != "" {
+		if s[0] != '[' {
+			return nil, errors.New("invalid filter parameter: " + strconv.Quote("filter"+s))
+		}
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return nil, errors.New("invalid filter parameter: " + strconv.Quote("filter"+s))
+		}
+		path = append(path, s[1:end])
+		s = s[end+1:]
+	}
+	return path, nil
+}
+
+func setBracket(node map[string]any, path []string, value any) error {
+	for i, segment := range path {
+		if segment == "" {
+			segment = strconv.Itoa(len(node))
+		}
+		if i == len(path)-1 {
+			if _, ok := node[segment]; ok {
+				return errors.New("conflicting values")
+			}
+			if value == "{}" {
+				value = map[string]any{}
+			}
+			node[segment] = value
+			return nil
+		}
+		child, ok := node[segment].(map[string]any)
+		if !ok {
+			if _, set := node[segment]; set {
+				return errors.New("conflicting values")
+			}
+			child = map[string]any{}
+			node[segment] = child
+		}
+		node = child
+	}
+	return nil
+}
+
+// bracketLists turns objects keyed 0 to n-1 into arrays. Field names never
+// start with a digit, so such keys are always indexes.
+func bracketLists(v any) any {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	for k, o := range obj {
+		obj[k] = bracketLists(o)
+	}
+	if len(obj) == 0 {
+		return obj
+	}
+	list := make([]any, len(obj))
+	for k, o := range obj {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(list) || list[i] != nil {
+			return obj
+		}
+		list[i] = o
+	}
+	return list
+}
@@ -0,0 +1,96 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBracketFilters(t *testing.T) {
+	query := DirectusQuery{
+		Filter: And{
+			Filter{"status": {OP_eq: "published"}, "author.name": {OP_in: []string{"a", "b"}}},
+			Or{Filter{"views": {OP_gt: 10}}, Filter{"featured": {OP_null: true}}},
+		},
+		Limit: 10,
+	}
+	v, err := query.BuildBracketQuery()
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"filter[_and][0][status][_eq]":             {"published"},
+		"filter[_and][0][author][name][_in][0]":    {"a"},
+		"filter[_and][0][author][name][_in][1]":    {"b"},
+		"filter[_and][1][_or][0][views][_gt]":      {"10"},
+		"filter[_and][1][_or][1][featured][_null]": {"true"},
+		"limit": {"10"},
+	}, v)
+
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, FilterNode(And{
+		Filter{"status": {OP_eq: "published"}, "author.name": {OP_in: []any{"a", "b"}}},
+		Or{Filter{"views": {OP_gt: "10"}}, Filter{"featured": {OP_null: "true"}}},
+	}), parsed.Filter)
+
+	// the SEARCH fallback sends the filter as JSON again
+	body, err := searchBody(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"query":{"limit":10,"filter":{"_and":[{"status":{"_eq":"published"},"author":{"name":{"_in":["a","b"]}}},{"_or":[{"views":{"_gt":"10"}},{"featured":{"_null":"true"}}]}]}}}`, string(body))
+
+	// repeated and appended values become lists
+	parsed, err = ParseQuery(url.Values{"filter[id][_in]": {"1", "2"}, "filter[tags][_in][]": {"x"}})
+	require.NoError(t, err)
+	require.Equal(t, FilterNode(Filter{"id": {OP_in: []any{"1", "2"}}, "tags": {OP_in: []any{"x"}}}), parsed.Filter)
+
+	for _, bad := range []url.Values{
+		{"filter": {`{}`}, "filter[id][_eq]": {"1"}},
+		{"filter[id][_eq": {"1"}},
+		{"filter[id]x[_eq]": {"1"}},
+		{"filter[id]": {"1"}, "filter[id][_eq]": {"1"}},
+		{"filter[id][_foo]": {"1"}},
+	} {
+		_, err := ParseQuery(bad)
+		require.Error(t, err, bad.Encode())
+	}
+
+	var rawQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithBracketFilters())
+	require.NoError(t, err)
+	_, _, err = Items[article](client, "articles").List(context.Background(), DirectusQuery{Filter: Filter{"status": {OP_eq: "published"}}})
+	require.NoError(t, err)
+	require.Equal(t, "filter%5Bstatus%5D%5B_eq%5D=published&limit=1000", rawQuery)
+
+	// empty lists have no bracket form, so the filter stays JSON
+	_, err = Items[article](client, "articles").Count(context.Background(), Filter{"id": {OP_in: []int{}}})
+	require.NoError(t, err)
+	q, err := url.ParseQuery(rawQuery)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":{"_in":[]}}`, q.Get("filter"))
+}
+
+func TestBracketFiltersEmptyLists(t *testing.T) {
+	for _, tt := range []struct {
+		filter FilterNode
+		want   string
+	}{
+		{Filter{"id": {OP_in: []int{}}}, `{"id":{"_in":[]}}`},
+		{Filter{"status": {OP_eq: "published"}, "id": {OP_nin: []int{}}}, `{"status":{"_eq":"published"},"id":{"_nin":[]}}`},
+		{And{}, `{"_and":[]}`},
+		{Or{Filter{"status": {OP_eq: "published"}}, And{}}, `{"_or":[{"status":{"_eq":"published"}},{"_and":[]}]}`},
+	} {
+		v, err := (&DirectusQuery{Filter: tt.filter}).BuildBracketQuery()
+		require.NoError(t, err)
+		require.False(t, hasBracketFilter(v), v.Encode())
+		require.JSONEq(t, tt.want, v.Get("filter"))
+	}
+}
@@ -0,0 +1,109 @@
+package directus_client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting Directus while the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("directus circuit breaker is open")
+
+// CircuitBreakerPolicy configures WithCircuitBreaker. Zero fields take their
+// defaults.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit, 5 by default.
+	FailureThreshold int
+	// ProbeInterval is how long the circuit stays open before a single probe
+	// request is let through, 10s by default.
+	ProbeInterval time.Duration
+}
+
+func (p *CircuitBreakerPolicy) applyDefault() {
+	if p.FailureThreshold == 0 {
+		p.FailureThreshold = 5
+	}
+	if p.ProbeInterval == 0 {
+		p.ProbeInterval = 10 * time.Second
+	}
+}
+
+// WithCircuitBreaker fast-fails requests with ErrCircuitOpen once Directus
+// keeps failing with network errors or 5xx responses, instead of having
+// every request wait for the timeout. The breaker sits behind the cache, so
+// cached queries are still served while it is open.
+func WithCircuitBreaker(policy CircuitBreakerPolicy) ClientOption {
+	return func(d *DirectusClient) {
+		policy.applyDefault()
+		d.breaker = &circuitBreaker{policy: policy}
+	}
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+	log    Logger
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether a request may be sent. In the half open state only
+// the probe is let through until its outcome is recorded.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.policy.ProbeInterval {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	failed := err != nil || resp.StatusCode >= 500
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		if b.state != circuitClosed {
+			b.log.Info("directus circuit breaker closed")
+		}
+		b.state, b.failures = circuitClosed, 0
+		return
+	}
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= b.policy.FailureThreshold) {
+		if b.state == circuitClosed {
+			b.log.Warn("directus circuit breaker opened", "error", err, "failures", b.failures)
+		}
+		b.state, b.openedAt = circuitOpen, time.Now()
+	}
+}
+
+// cancelled gives up a request that was aborted by its caller, which says
+// nothing about the health of Directus.
+func (b *circuitBreaker) cancelled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+	}
+}
@@ -0,0 +1,96 @@
+package directus_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		hits int64
+		down int32 = 1
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithCircuitBreaker(CircuitBreakerPolicy{
+		FailureThreshold: 2,
+		ProbeInterval:    50 * time.Millisecond,
+	}))
+	require.NoError(t, err)
+
+	query := func() error {
+		resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	require.NoError(t, query())
+	require.NoError(t, query())
+	require.ErrorIs(t, query(), ErrCircuitOpen)
+	require.EqualValues(t, 2, atomic.LoadInt64(&hits))
+
+	// the probe fails and the circuit opens again
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, query())
+	require.ErrorIs(t, query(), ErrCircuitOpen)
+	require.EqualValues(t, 3, atomic.LoadInt64(&hits))
+
+	atomic.StoreInt32(&down, 0)
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, query())
+	require.NoError(t, query())
+	require.EqualValues(t, 5, atomic.LoadInt64(&hits))
+}
+
+func TestCircuitBreakerProbeNotSent(t *testing.T) {
+	var down int32 = 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithSearchFallback(1), WithCircuitBreaker(CircuitBreakerPolicy{
+		FailureThreshold: 1,
+		ProbeInterval:    50 * time.Millisecond,
+	}))
+	require.NoError(t, err)
+
+	query := func() error {
+		resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	require.NoError(t, query())
+	require.ErrorIs(t, query(), ErrCircuitOpen)
+
+	// a request failing before it is sent must not leave the breaker half open
+	atomic.StoreInt32(&down, 0)
+	time.Sleep(60 * time.Millisecond)
+	req, err := http.NewRequest("GET", srv.URL+"/items/user?limit=many", nil)
+	require.NoError(t, err)
+	_, err = client.fetch(req, "user", true)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCircuitOpen)
+	require.NoError(t, query())
+	require.NoError(t, query())
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"github.com/cespare/xxhash/v2"
 	"github.com/go-redis/redis/v8"
-	"github.com/rs/zerolog/log"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,6 +20,35 @@ type CacheService interface {
 	Clear() error
 }
 
+// StringCacheService is implemented by CacheService backends that can return
+// a cached value without copying it into a new []byte. Redis replies already
+// arrive as strings, so serving them as such saves a copy per cache hit.
+type StringCacheService interface {
+	GetString(key string) (string, error)
+}
+
+// ContextCacheService is implemented by CacheService backends whose calls can
+// be cancelled and carry deadlines and trace metadata. The context-less
+// methods are used otherwise.
+type ContextCacheService interface {
+	GetContext(ctx context.Context, key string) ([]byte, error)
+	SetContext(ctx context.Context, key string, value []byte) error
+	DelContext(ctx context.Context, key string) error
+}
+
+// PrefixCacheService is implemented by CacheService backends that can delete
+// all keys starting with a prefix, which the cache of
+// NewRefreshableQueryCache uses to drop the queries of a collection. Backends
+// without it are cleared entirely instead.
+type PrefixCacheService interface {
+	DelPrefix(ctx context.Context, prefix string) error
+}
+
+// the context variant of StringCacheService
+type contextStringCacheService interface {
+	GetStringContext(ctx context.Context, key string) (string, error)
+}
+
 type redisCacheService struct {
 	r        redis.UniversalClient
 	keyspace string
@@ -28,6 +57,9 @@ type redisCacheService struct {
 }
 
 var _ CacheService = (*redisCacheService)(nil)
+var _ StringCacheService = (*redisCacheService)(nil)
+var _ ContextCacheService = (*redisCacheService)(nil)
+var _ PrefixCacheService = (*redisCacheService)(nil)
 
 type RedisCacheServiceOption struct {
 	Keyspace    string
@@ -52,61 +84,210 @@ func NewRedisCacheService(r redis.UniversalClient, option RedisCacheServiceOptio
 	return &cs, nil
 }
 func (r redisCacheService) Get(key string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	return r.GetContext(context.Background(), key)
+}
+func (r redisCacheService) GetContext(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 	return r.r.Get(ctx, r.keyspace+":"+key).Bytes()
 }
+func (r redisCacheService) GetString(key string) (string, error) {
+	return r.GetStringContext(context.Background(), key)
+}
+func (r redisCacheService) GetStringContext(ctx context.Context, key string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.r.Get(ctx, r.keyspace+":"+key).Result()
+}
 func (r redisCacheService) Set(key string, value []byte) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	return r.SetContext(context.Background(), key, value)
+}
+func (r redisCacheService) SetContext(ctx context.Context, key string, value []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 	return r.r.Set(ctx, r.keyspace+":"+key, value, r.ttl).Err()
 }
 func (r redisCacheService) Del(key string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	return r.DelContext(context.Background(), key)
+}
+func (r redisCacheService) DelContext(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 	return r.r.Del(ctx, r.keyspace+":"+key).Err()
 }
+
+// DelPrefix deletes the keys starting with prefix. DEL doesn't expand globs,
+// so the keys are found with SCAN.
+func (r redisCacheService) DelPrefix(ctx context.Context, prefix string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.delMatching(ctx, r.keyspace+":"+globEscaper.Replace(prefix)+"*")
+}
+
+// Clear deletes the keys of the keyspace.
 func (r redisCacheService) Clear() error {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
-	_, err := r.r.Pipelined(ctx, func(p redis.Pipeliner) error {
-		keys, err := p.Keys(ctx, r.keyspace+":*").Result()
-		if err != nil {
-			return err
+	return r.delMatching(ctx, r.keyspace+":*")
+}
+
+// globEscaper escapes the special characters of SCAN patterns.
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// delMatching deletes the keys matching pattern, on every master of a
+// cluster.
+func (r redisCacheService) delMatching(ctx context.Context, pattern string) error {
+	if c, ok := r.r.(*redis.ClusterClient); ok {
+		return c.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return delMatchingNode(ctx, node, pattern, true)
+		})
+	}
+	return delMatchingNode(ctx, r.r, pattern, false)
+}
+
+// delBatch is the number of keys delMatching scans and deletes at once.
+const delBatch = 1000
+
+// delMatchingNode deletes the keys matching pattern on the node c. Unlike
+// KEYS, SCAN doesn't block the node on large databases. Cluster nodes reject
+// a DEL of keys in different hash slots, so with perKey each key gets a DEL
+// of its own, pipelined.
+func delMatchingNode(ctx context.Context, c redis.Cmdable, pattern string, perKey bool) error {
+	del := func(keys []string) error {
+		if !perKey {
+			return c.Del(ctx, keys...).Err()
 		}
-		return p.Del(ctx, keys...).Err()
-	})
-	return err
+		_, err := c.Pipelined(ctx, func(p redis.Pipeliner) error {
+			for _, key := range keys {
+				p.Del(ctx, key)
+			}
+			return nil
+		})
+		return err
+	}
+	iter := c.Scan(ctx, 0, pattern, delBatch).Iterator()
+	keys := make([]string, 0, delBatch)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == delBatch {
+			if err := del(keys); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return del(keys)
 }
 
+// QueryCache caches response bodies per collection and query.
 type QueryCache interface {
 	Get(collection, rawQuery string) ([]byte, error)
 	Set(collection, rawQuery string, value []byte) error
 }
+
+// StringQueryCache is the QueryCache counterpart of StringCacheService.
+type StringQueryCache interface {
+	GetString(collection, rawQuery string) (string, error)
+}
+
+// ContextQueryCache is the QueryCache counterpart of ContextCacheService.
+// DirectusClient passes the request context to caches implementing it.
+type ContextQueryCache interface {
+	GetContext(ctx context.Context, collection, rawQuery string) ([]byte, error)
+	SetContext(ctx context.Context, collection, rawQuery string, value []byte) error
+}
+
+type contextStringQueryCache interface {
+	GetStringContext(ctx context.Context, collection, rawQuery string) (string, error)
+}
+
+// cacheLookup returns the cached response for a query, or nil on a miss.
+func cacheLookup(ctx context.Context, c QueryCache, collection, rawQuery string) *http.Response {
+	switch c := c.(type) {
+	case contextStringQueryCache:
+		if data, _ := c.GetStringContext(ctx, collection, rawQuery); len(data) > 0 {
+			return cachedStringResponse(data)
+		}
+	case StringQueryCache:
+		if data, _ := c.GetString(collection, rawQuery); len(data) > 0 {
+			return cachedStringResponse(data)
+		}
+	case ContextQueryCache:
+		if data, _ := c.GetContext(ctx, collection, rawQuery); len(data) > 0 {
+			return cachedResponse(data)
+		}
+	default:
+		if data, _ := c.Get(collection, rawQuery); len(data) > 0 {
+			return cachedResponse(data)
+		}
+	}
+	return nil
+}
+
+func cacheStore(ctx context.Context, c QueryCache, collection, rawQuery string, data []byte) error {
+	if c, ok := c.(ContextQueryCache); ok {
+		return c.SetContext(ctx, collection, rawQuery, data)
+	}
+	return c.Set(collection, rawQuery, data)
+}
+
 type noopCacheService int
 
+var errNoopCache = errors.New("get item from noop cache")
+
 func (noopCacheService) Get(collection, rawQuery string) ([]byte, error) {
-	return nil, errors.New("get item from noop cache")
+	return nil, errNoopCache
 }
 func (noopCacheService) Set(collection, rawQuery string, value []byte) error {
 	return nil
 }
 
+// ClearableQueryCache is implemented by QueryCaches that can drop all cached
+// responses, such as those of NewRefreshableQueryCache.
+type ClearableQueryCache interface {
+	Clear() error
+}
+
+var _ ContextQueryCache = (*refreshableQueryCache)(nil)
+var _ StringQueryCache = (*refreshableQueryCache)(nil)
+var _ ClearableQueryCache = (*refreshableQueryCache)(nil)
+
 type refreshableQueryCache struct {
 	mu                  sync.RWMutex
 	store               CacheService
 	observedCollections map[string]struct{}
 	wes                 *WebhookEventServer
+	log                 Logger
+}
+
+// QueryCacheOption configures the cache created by NewRefreshableQueryCache.
+type QueryCacheOption func(*refreshableQueryCache)
+
+// WithCacheLogger sets the logger of the cache. Nothing is logged by default.
+func WithCacheLogger(l Logger) QueryCacheOption {
+	return func(q *refreshableQueryCache) {
+		q.log = l
+	}
 }
 
 func NewNoopQueryCache() QueryCache {
 	return noopCacheService(0)
 }
-func NewRefreshableQueryCache(store CacheService, wes *WebhookEventServer) (QueryCache, error) {
+func NewRefreshableQueryCache(store CacheService, wes *WebhookEventServer, opts ...QueryCacheOption) (QueryCache, error) {
 	r := &refreshableQueryCache{
 		store:               store,
 		observedCollections: make(map[string]struct{}),
 		wes:                 wes,
+		log:                 nopLogger{},
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
 	return r, nil
 }
@@ -115,41 +296,108 @@ func queryKey(c string, q string) string {
 	split := strings.Split(c, "/")
 	if len(split) == 2 {
 		c = split[0]
-		q = fmt.Sprintf(`{"id": {"_eq": %s}}`, split[1])
+		// keep the parameters, e.g. fields or version, apart per item
+		q = fmt.Sprintf(`{"id": {"_eq": %s}}`, keyJSON(split[1])) + "?" + q
 	}
 	h := xxhash.New()
 	h.Write([]byte(q))
 
 	return c + ":" + strconv.FormatUint(h.Sum64(), 16)
 }
+
+// keyJSON quotes non-numeric primary keys of item paths.
+func keyJSON(key string) string {
+	if _, err := strconv.ParseInt(key, 10, 64); err == nil {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
 func (q *refreshableQueryCache) Get(collection string, rawQuery string) ([]byte, error) {
+	return q.GetContext(context.Background(), collection, rawQuery)
+}
+func (q *refreshableQueryCache) GetContext(ctx context.Context, collection string, rawQuery string) ([]byte, error) {
 	key := queryKey(collection, rawQuery)
+	if cs, ok := q.store.(ContextCacheService); ok {
+		return cs.GetContext(ctx, key)
+	}
 	return q.store.Get(key)
 }
+
+// GetString avoids copying the cached value when the store supports it.
+func (q *refreshableQueryCache) GetString(collection string, rawQuery string) (string, error) {
+	return q.GetStringContext(context.Background(), collection, rawQuery)
+}
+func (q *refreshableQueryCache) GetStringContext(ctx context.Context, collection string, rawQuery string) (string, error) {
+	key := queryKey(collection, rawQuery)
+	switch sc := q.store.(type) {
+	case contextStringCacheService:
+		return sc.GetStringContext(ctx, key)
+	case StringCacheService:
+		return sc.GetString(key)
+	}
+	b, err := q.GetContext(ctx, collection, rawQuery)
+	return string(b), err
+}
 func (q *refreshableQueryCache) Set(collection string, rawQuery string, data []byte) error {
-	if err := q.store.Set(queryKey(collection, rawQuery), data); err != nil {
+	return q.SetContext(context.Background(), collection, rawQuery, data)
+}
+func (q *refreshableQueryCache) SetContext(ctx context.Context, collection string, rawQuery string, data []byte) error {
+	key := queryKey(collection, rawQuery)
+	var err error
+	if cs, ok := q.store.(ContextCacheService); ok {
+		err = cs.SetContext(ctx, key, data)
+	} else {
+		err = q.store.Set(key, data)
+	}
+	if err != nil {
 		return err
 	}
 
+	// items are pruned together with their collection
+	collection, _, _ = strings.Cut(collection, "/")
 	q.mu.Lock()
-	if _, ok := q.observedCollections[collection]; ok {
-		return nil
-	}
+	_, observed := q.observedCollections[collection]
 	q.observedCollections[collection] = struct{}{}
 	q.mu.Unlock()
+	if observed {
+		return nil
+	}
 
-	err := q.wes.AddObserver(collection, func(we WebhookEvent) {
-		q.pruneCollection(collection)
+	err = q.wes.AddObserverContext(collection, func(ctx context.Context, we WebhookEvent) {
+		if err := q.pruneCollection(ctx, collection); err != nil {
+			q.log.Warn("failed to prune cache", "error", err, "collection", collection)
+		}
 	})
 	if err != nil {
-		log.Warn().Str("collection", collection).Msg("failed to add observer")
+		q.log.Warn("failed to add observer", "error", err, "collection", collection)
 	}
 
 	return nil
 }
-func (q *refreshableQueryCache) pruneCollection(c string) error {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	delete(q.observedCollections, c)
-	return q.store.Del(c + ":" + "*")
-}
\ No newline at end of file
+
+// Clear drops all cached queries.
+func (q *refreshableQueryCache) Clear() error {
+	return q.store.Clear()
+}
+
+// pruneCollection drops cached queries of c. The observer stays registered,
+// so c remains in observedCollections.
+func (q *refreshableQueryCache) pruneCollection(ctx context.Context, c string) error {
+	if ps, ok := q.store.(PrefixCacheService); ok {
+		return ps.DelPrefix(ctx, c+":")
+	}
+	return q.store.Clear()
+}
+
+type bypassCacheKey struct{}
+
+// withoutCache makes requests with ctx skip the cache, for reads that must
+// see the current state, such as the lookup of Upsert.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	return ctx.Value(bypassCacheKey{}) != nil
+}
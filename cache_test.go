@@ -0,0 +1,163 @@
+package directus_client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCluster is a single node Redis Cluster serving the commands the cache
+// uses, rejecting multi-key commands across hash slots like Redis does.
+type fakeCluster struct {
+	ln net.Listener
+
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+func newFakeCluster(t *testing.T, keys ...string) *fakeCluster {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeCluster{ln: ln, keys: make(map[string]string)}
+	for _, k := range keys {
+		f.keys[k] = "v"
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeCluster) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeCluster) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch strings.ToLower(args[0]) {
+	case "cluster":
+		host, port, _ := net.SplitHostPort(f.ln.Addr().String())
+		return fmt.Sprintf("*1\r\n*3\r\n:0\r\n:16383\r\n*3\r\n$%d\r\n%s\r\n:%s\r\n$4\r\nnode\r\n", len(host), host, port)
+	case "command":
+		return "*0\r\n"
+	case "scan":
+		var match []string
+		for k := range f.keys {
+			if ok, _ := path.Match(args[3], k); ok {
+				match = append(match, k)
+			}
+		}
+		reply := fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n", len(match))
+		for _, k := range match {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(k), k)
+		}
+		return reply
+	case "del":
+		for _, k := range args[2:] {
+			if keySlot(k) != keySlot(args[1]) {
+				return "-CROSSSLOT Keys in request don't hash to the same slot\r\n"
+			}
+		}
+		n := 0
+		for _, k := range args[1:] {
+			if _, ok := f.keys[k]; ok {
+				delete(f.keys, k)
+				n++
+			}
+		}
+		return ":" + strconv.Itoa(n) + "\r\n"
+	}
+	return "-ERR unknown command\r\n"
+}
+
+func (f *fakeCluster) remaining() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for k := range f.keys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+// keySlot is the hash slot of key, ignoring hash tags.
+func keySlot(key string) int {
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return int(crc) % 16384
+}
+
+func TestRedisCacheClusterDelete(t *testing.T) {
+	f := newFakeCluster(t, "directus:articles:a", "directus:articles:b", "directus:articles:c", "directus:user:a", "other:x")
+	require.NotEqual(t, keySlot("directus:articles:a"), keySlot("directus:articles:b"))
+	rdb := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{f.ln.Addr().String()}})
+	defer rdb.Close()
+	cs, err := NewRedisCacheService(rdb, RedisCacheServiceOption{})
+	require.NoError(t, err)
+
+	require.NoError(t, cs.(PrefixCacheService).DelPrefix(context.Background(), "articles:"))
+	require.ElementsMatch(t, []string{"directus:user:a", "other:x"}, f.remaining())
+
+	require.NoError(t, cs.Clear())
+	require.Equal(t, []string{"other:x"}, f.remaining())
+}
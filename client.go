@@ -2,11 +2,11 @@ package directus_client
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"github.com/rs/zerolog/log"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strconv"
 	"strings"
@@ -15,15 +15,62 @@ import (
 
 const ITEMS_MAX_LIMIT = 1000
 
+// ITEMS_NO_LIMIT is the limit Directus reads as "all items". Queries only
+// accept it from clients configured with WithMaxLimit(ITEMS_NO_LIMIT).
+const ITEMS_NO_LIMIT = -1
+
+// Querier is the part of DirectusClient that services depend on. Accept it
+// instead of *DirectusClient so tests can swap in mocks.Querier or
+// mocks.FakeQuerier without a running Directus.
+//
+//go:generate mockery --name Querier --output ./mocks
+type Querier interface {
+	Call(req *http.Request) (*http.Response, error)
+	CallContext(ctx context.Context, req *http.Request) (*http.Response, error)
+	Query(method string, collection string, query DirectusQuery, input io.Reader) (*http.Response, error)
+	QueryContext(ctx context.Context, method string, collection string, query DirectusQuery, input io.Reader) (*http.Response, error)
+}
+
+var _ Querier = (*DirectusClient)(nil)
+
+// DirectusClient is safe for concurrent use by multiple goroutines, and so
+// are the QueryCache implementations of this package. All clients share one
+// keep-alive transport unless WithHTTPClient is used.
 type DirectusClient struct {
-	client  *http.Client
-	baseURL *url.URL
-	token   string
-	cache   QueryCache
+	client          *http.Client
+	baseURL         *url.URL
+	tokens          TokenProvider
+	cache           QueryCache
+	accept          string
+	compressedCache bool
+	inflight        *inflightGroup
+	stats           *connStats
+	endpoints       *endpointPool
+	healthInterval  time.Duration
+	retry           *RetryPolicy
+	breaker         *circuitBreaker
+	middleware      []Middleware
+	chain           RoundTripFunc
+	optErr          error
+	log             Logger
+	metrics         *Metrics
+	debug           bool
+	derived         bool
+	searchThreshold int
+	maxLimit        int
+	bracketFilters  bool
+	policy          *QueryPolicy
+	maxResponseSize int64
+	hooks           []Hooks
+
+	impersonateUserHeader string
+	impersonateRoleHeader string
 }
 
+// DirectusResult is a decoded Directus response. Meta is set if the query
+// requested counts with DirectusQuery.Meta.
 type DirectusResult[T any] struct {
-	Meta   *MetaResult     `json:"meta,omitempty"`
+	Meta   *Meta           `json:"meta,omitempty"`
 	Data   T               `json:"data"`
 	Errors []DirectusError `json:"errors,omitempty"`
 }
@@ -42,16 +89,51 @@ func ReadResult[T any](r *http.Response) DirectusResult[T] {
 		}
 	}
 
+	body, err := responseBody(r)
+	if err != nil {
+		return DirectusResult[T]{Errors: []DirectusError{{Message: err.Error()}}}
+	}
 	var result DirectusResult[T]
-	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+	if err := decoderFor(r.Header.Get("Content-Type"))(body, &result); err != nil {
 		return DirectusResult[T]{Errors: []DirectusError{{Message: err.Error()}}}
 	}
 	return result
 }
-func NewDirectusClient(baseURL string, token string, cache QueryCache) (*DirectusClient, error) {
+
+// sharedTransport keeps enough idle connections per host for a proxy serving
+// many concurrent requests; http.DefaultTransport keeps only two, so bursts
+// would constantly reconnect to Directus.
+var sharedTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = 512
+	t.MaxIdleConnsPerHost = 128
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}()
+
+type ClientOption func(*DirectusClient)
+
+// WithHTTPClient replaces the http.Client used to reach Directus, e.g. to
+// record traffic with directustest.Recorder.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(d *DirectusClient) {
+		d.client = c
+	}
+}
+
+func NewDirectusClient(baseURL string, token string, cache QueryCache, opts ...ClientOption) (*DirectusClient, error) {
 	if token == "" {
 		return nil, errors.New("token is required")
 	}
+	d, err := newDirectusClient(baseURL, cache, opts...)
+	if err != nil {
+		return nil, err
+	}
+	d.tokens = StaticToken(token)
+	return d, nil
+}
+
+func newDirectusClient(baseURL string, cache QueryCache, opts ...ClientOption) (*DirectusClient, error) {
 	if strings.HasSuffix(baseURL, "/") {
 		baseURL = baseURL[:len(baseURL)-1]
 	}
@@ -59,19 +141,72 @@ func NewDirectusClient(baseURL string, token string, cache QueryCache) (*Directu
 	if err != nil {
 		return nil, err
 	}
-	return &DirectusClient{
+	d := &DirectusClient{
 		client: &http.Client{
-			Timeout: time.Second * 10,
+			Transport: sharedTransport,
+			Timeout:   time.Second * 10,
 		},
 		baseURL: u,
-		token:   token,
 		cache:   cache,
-	}, nil
+		stats:   newConnStats(),
+		endpoints: &endpointPool{
+			primary: &endpoint{url: u},
+			stop:    make(chan struct{}),
+		},
+		log:      nopLogger{},
+		maxLimit: ITEMS_MAX_LIMIT,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.optErr != nil {
+		return nil, d.optErr
+	}
+	if d.breaker != nil {
+		d.breaker.log = d.log
+	}
+	if d.healthInterval > 0 {
+		d.startHealthCheck()
+	}
+	return d, nil
 }
 
+// WithToken returns a client sending requests with token instead, e.g. on
+// behalf of a user of a multi-tenant service. It shares the transport,
+// endpoints, retry policy, circuit breaker, middleware, logger and metrics
+// of d and is cheap enough to create per request. Directus filters responses
+// by the permissions of the token, so the derived client neither uses the
+// query cache nor deduplicates requests with d. Close is a no-op on it.
+func (d *DirectusClient) WithToken(token string) *DirectusClient {
+	c := *d
+	c.tokens = StaticToken(token)
+	c.cache = NewNoopQueryCache()
+	if d.inflight != nil {
+		c.inflight = &inflightGroup{calls: make(map[string]*inflightCall)}
+	}
+	c.derived = true
+	if len(d.middleware) > 0 {
+		// the chain of d ends in d.roundTrip
+		c.middleware = nil
+		c.Use(d.middleware...)
+	}
+	return &c
+}
+
+// Call sends req with its own context, see CallContext.
 func (d *DirectusClient) Call(req *http.Request) (*http.Response, error) {
+	return d.CallContext(req.Context(), req)
+}
+
+// CallContext sends req to Directus, or serves it from the cache. ctx
+// cancels the request and is passed on to caches implementing
+// ContextQueryCache.
+func (d *DirectusClient) CallContext(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if ctx != req.Context() {
+		req = req.WithContext(ctx)
+	}
 	switch req.Method {
-	case "GET", "POST", "PATCH", "DELETE":
+	case "GET", "HEAD", "SEARCH", "POST", "PATCH", "DELETE":
 		break
 	default:
 		return nil, errors.New("invalid method")
@@ -82,8 +217,22 @@ func (d *DirectusClient) Call(req *http.Request) (*http.Response, error) {
 	if req.Header == nil {
 		req.Header = http.Header{}
 	}
-	req.Header.Set("Authorization", "Bearer "+d.token)
+	if fa, ok := forwardedAuthFromContext(ctx); ok {
+		setForwardedAuth(req, fa)
+	} else {
+		token, err := d.accessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		d.setImpersonation(req)
+	}
 	req.Header.Set("Content-Type", "application/json")
+	if d.accept != "" {
+		req.Header.Set("Accept", d.accept)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	req = withRequestID(req)
 	req.RequestURI = ""
 	req.URL.Scheme = d.baseURL.Scheme
 	req.URL.Host = d.baseURL.Host
@@ -91,77 +240,250 @@ func (d *DirectusClient) Call(req *http.Request) (*http.Response, error) {
 	if req.URL.RawQuery == "" {
 		req.URL.RawQuery = "limit=" + strconv.Itoa(ITEMS_MAX_LIMIT)
 	}
+	if d.chain != nil {
+		return d.dispatch(req, d.chain)
+	}
+	return d.dispatch(req, d.roundTrip)
+}
 
+// roundTrip serves req from the cache or Directus. It is the innermost
+// RoundTripFunc of the middleware chain.
+func (d *DirectusClient) roundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
 	split := strings.SplitN(req.URL.Path, "items/", 2)
 	if len(split) != 2 {
 		return nil, errors.New("invalid url")
 	}
 	collection := split[1]
 
-	data, err := d.cache.Get(collection, req.URL.RawQuery)
-	if len(data) > 0 {
-		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(data))}, nil
+	_, noCache := d.cache.(noopCacheService)
+	// only reads are cached: HEAD responses have no body, SEARCH ones depend
+	// on it, and mutations must reach Directus every time
+	noCache = noCache || req.Method != "GET"
+	// responses to other users' credentials must not be shared
+	_, forwarded := forwardedAuthFromContext(ctx)
+	_, impersonated := impersonationFromContext(ctx)
+	personal := forwarded || impersonated
+	noCache = noCache || personal || cacheBypassed(ctx)
+	if !noCache {
+		resp := cacheLookup(ctx, d.cache, collection, req.URL.RawQuery)
+		d.metrics.observeCache(collection, resp != nil)
+		if resp != nil {
+			if d.debug {
+				d.log.Debug("directus cache hit", "request_id", RequestIDFromContext(ctx), "collection", collection, "query", req.URL.RawQuery)
+			}
+			return resp, nil
+		}
+	}
+	if req.Method == "GET" && d.inflight != nil && !personal {
+		return d.inflight.do(inflightKey(collection, req.URL.RawQuery), req, func() (*http.Response, error) {
+			return d.fetch(req, collection, noCache)
+		})
+	}
+	return d.fetch(req, collection, noCache)
+}
+
+// fetch sends req to Directus, filling the cache as the body is read.
+func (d *DirectusClient) fetch(req *http.Request, collection string, noCache bool) (*http.Response, error) {
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), d.stats.trace))
+	// build the outgoing request first, a probe let through by the breaker
+	// must end in record or cancelled
+	out := req
+	if d.searchThreshold > 0 && req.Method == "GET" && len(req.URL.RawQuery) > d.searchThreshold {
+		var err error
+		if out, err = asSearch(req); err != nil {
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
+	}
+	if d.breaker != nil {
+		if err := d.breaker.allow(); err != nil {
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
+	}
+	if d.debug {
+		if err := d.dumpRequest(out); err != nil {
+			if d.breaker != nil {
+				d.breaker.cancelled()
+			}
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
+	}
+	start := time.Now()
+	resp, err := d.send(out)
+	if err == nil {
+		d.metrics.observeRequest(collection, req.Method, resp.StatusCode, nil, start)
+	} else {
+		d.metrics.observeRequest(collection, req.Method, 0, err, start)
+	}
+	if d.breaker != nil {
+		if req.Context().Err() != nil {
+			d.breaker.cancelled()
+		} else {
+			d.breaker.record(resp, err)
+		}
 	}
-	resp, err := d.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+	}
+	d.stats.observe(resp)
+	if !d.compressedCache {
+		if err := decompressBody(resp); err != nil {
+			resp.Body.Close()
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
+	}
+	if err := d.limitBody(resp); err != nil {
+		return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+	}
+	if d.debug {
+		if err := d.dumpResponse(req, resp, start); err != nil {
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		copied := new(bytes.Buffer)
-		io.Copy(copied, resp.Body)
-		resp.Body = io.NopCloser(copied)
-		err := d.cache.Set(collection, req.URL.RawQuery, copied.Bytes())
-		if err != nil {
-			log.Warn().Err(err).Str("path", req.URL.Path).Msg("failed to set cache")
+	if resp.StatusCode == http.StatusOK && !noCache {
+		rawQuery := req.URL.RawQuery
+		body := newCachingBody(resp.Body, func(data []byte) {
+			if err := cacheStore(req.Context(), d.cache, collection, rawQuery, data); err != nil {
+				d.log.Warn("failed to set cache", "error", err, "path", req.URL.Path, "request_id", RequestIDFromContext(req.Context()))
+			}
+		})
+		if err := writeCacheEnvelope(body.buf, resp.Header); err != nil {
+			resp.Body.Close()
+			return nil, err
 		}
+		resp.Body = body
 	}
 
 	return resp, nil
 }
 
-func (d *DirectusClient) Proxy(stripN int) http.Handler {
+// Proxy serves Directus items requests with the path prefix of stripN
+// segments removed, using the token of d unless WithForwardedAuth is given.
+func (d *DirectusClient) Proxy(stripN int, opts ...ProxyOption) http.Handler {
+	var cfg proxyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
 		p := strings.SplitN(r.URL.Path, "/", stripN+2)
 		if len(p) == stripN+2 {
 			r.URL.Path = "/" + p[len(p)-1]
 		}
+		acceptEncoding := r.Header.Get("Accept-Encoding")
+		if r.Header.Get(RequestIDHeader) == "" {
+			r.Header.Set(RequestIDHeader, newRequestID())
+		}
+		w.Header().Set(RequestIDHeader, r.Header.Get(RequestIDHeader))
+		if cfg.forwardAuth {
+			r = withForwardedAuth(r)
+		}
+		if cfg.policy != nil {
+			if err := applyProxyPolicy(cfg.policy, r); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				d.metrics.observeProxy(http.StatusBadRequest, start)
+				return
+			}
+		}
 		resp, err := d.Call(r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			d.metrics.observeProxy(http.StatusInternalServerError, start)
 			return
 		}
-		w.WriteHeader(resp.StatusCode)
+		defer resp.Body.Close()
+		var body io.Reader = resp.Body
+		if enc := resp.Header.Get("Content-Encoding"); enc != "" && !acceptsEncoding(acceptEncoding, enc) {
+			if body, err = responseBody(resp); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				d.metrics.observeProxy(http.StatusBadGateway, start)
+				return
+			}
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+		}
 		for k, v := range resp.Header {
 			for _, v := range v {
 				w.Header().Add(k, v)
 			}
 		}
-		io.Copy(w, resp.Body)
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, body)
+		d.metrics.observeProxy(resp.StatusCode, start)
 	})
 }
 
 func (d *DirectusClient) Query(method string, collection string, query DirectusQuery, input io.Reader) (*http.Response, error) {
-	if err := query.validate(); err != nil {
+	return d.QueryContext(context.Background(), method, collection, query, input)
+}
+
+func (d *DirectusClient) QueryContext(ctx context.Context, method string, collection string, query DirectusQuery, input io.Reader) (*http.Response, error) {
+	if err := d.checkQuery(&query); err != nil {
 		return nil, err
 	}
 	v, err := query.BuildQuery()
 	if err != nil {
 		return nil, err
 	}
+	if method == "SEARCH" && input == nil {
+		body, err := searchBody(v)
+		if err != nil {
+			return nil, err
+		}
+		return d.CallContext(ctx, d.newItemsRequest(ctx, method, collection, nil, bytes.NewReader(body)))
+	}
+	if d.bracketFilters {
+		if err := bracketFilter(v); err != nil {
+			return nil, err
+		}
+	}
+	return d.CallContext(ctx, d.newItemsRequest(ctx, method, collection, v, input))
+}
+
+func (d *DirectusClient) newItemsRequest(ctx context.Context, method string, collection string, v url.Values, input io.Reader) *http.Request {
 	u := new(url.URL)
 	*u = *d.baseURL
 	u.Path = "/items/" + collection
 	u.RawQuery = v.Encode()
-	r := &http.Request{Method: method, URL: u}
+	r := (&http.Request{Method: method, URL: u}).WithContext(ctx)
 	if input != nil {
 		r.Body = io.NopCloser(input)
+		r.GetBody = bodyReplayer(input)
+	}
+	return r
+}
+
+// bodyReplayer returns a GetBody for the in-memory readers http.NewRequest
+// knows about, so retries and failover can resend the body.
+func bodyReplayer(input io.Reader) func() (io.ReadCloser, error) {
+	var data []byte
+	switch r := input.(type) {
+	case *bytes.Buffer:
+		data = r.Bytes()
+	case *bytes.Reader:
+		snapshot := *r
+		return func() (io.ReadCloser, error) {
+			r := snapshot
+			return io.NopCloser(&r), nil
+		}
+	case *strings.Reader:
+		snapshot := *r
+		return func() (io.ReadCloser, error) {
+			r := snapshot
+			return io.NopCloser(&r), nil
+		}
+	default:
+		return nil
+	}
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
 	}
-	return d.Call(r)
 }
 
 type DirectusError struct {
-	Message string `json:"message"`
+	Message    string          `json:"message"`
+	Extensions ErrorExtensions `json:"extensions"`
 }
 type Fields []string
 type MetaField string
@@ -186,59 +508,122 @@ func (m *MetaField) Unmarshal(s string) error {
 	return nil
 }
 
-type MetaResult struct {
-	Meta struct {
-		TotalCount  *int `json:"total_count"`
-		FilterCount *int `json:"filter_count"`
-	}
-}
+// MetaResult is the former type of DirectusResult.Meta.
+//
+// Deprecated: use Meta.
+type MetaResult = Meta
 
 type DirectusQuery struct {
 	Fields      Fields
-	Filter      Filter
+	Filter      FilterNode
 	Sort        Fields
 	Limit       int
 	Offset      int
 	offsetIsSet bool
 	Page        int
 	pageIsSet   bool
+	cursor      *Pagination
 	Meta        *MetaField
+	Aggregate   Aggregate
+	GroupBy     Fields
+	Alias       Alias
+	Export      FileFormat
+	// Version reads the content version with that key, e.g. "draft",
+	// instead of the published items, with the changes of the version
+	// applied to them unless VersionRaw is set.
+	Version    string
+	VersionRaw bool
 }
 
 func (d *DirectusQuery) validate() error {
-	if d.Limit > ITEMS_MAX_LIMIT {
-		return errors.New("limit must be less than 100")
-	} else {
-		if d.Filter == nil {
-			return errors.New("limit must be set if filter is not set")
-		}
+	return d.validateLimit(ITEMS_MAX_LIMIT)
+}
+
+// validateLimit validates d against a server allowing at most maxLimit items
+// per request, any number if maxLimit is ITEMS_NO_LIMIT.
+func (d *DirectusQuery) validateLimit(maxLimit int) error {
+	switch {
+	case d.Limit < ITEMS_NO_LIMIT:
+		return errors.New("limit must be -1 for all items or positive")
+	case d.Limit == ITEMS_NO_LIMIT && maxLimit != ITEMS_NO_LIMIT:
+		return errors.New("limit -1 is not allowed, at most " + strconv.Itoa(maxLimit) + " items can be requested")
+	case maxLimit != ITEMS_NO_LIMIT && d.Limit > maxLimit:
+		return errors.New("limit must be at most " + strconv.Itoa(maxLimit))
 	}
 	if d.offsetIsSet && d.pageIsSet {
 		return errors.New("cannot specify both offset and page")
 	}
-	return nil
+	if d.offsetIsSet || d.pageIsSet || d.cursor != nil {
+		p := d.Pagination()
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateFieldList("fields", d.Fields, false); err != nil {
+		return err
+	}
+	if d.Filter != nil {
+		if err := validateFilter(d.Filter, ""); err != nil {
+			return err
+		}
+	}
+	return d.validateVersion()
 }
 
+// Limits applied by ParseQuery, which handles untrusted proxy traffic.
+const (
+	QUERY_MAX_FILTER_SIZE  = 16 << 10
+	QUERY_MAX_FILTER_DEPTH = 16
+	QUERY_MAX_FIELDS       = 256
+	QUERY_MAX_FIELD_LENGTH = 256
+	QUERY_MAX_OFFSET       = 1 << 24
+)
+
 func ParseQuery(q url.Values) (*DirectusQuery, error) {
 	var d DirectusQuery
 	fields := q.Get("fields")
 	if fields != "" {
 		d.Fields = strings.Split(fields, ",")
+		if err := validateFieldList("fields", d.Fields, false); err != nil {
+			return nil, err
+		}
 	}
 	filter := q.Get("filter")
+	if hasBracketFilter(q) {
+		if filter != "" {
+			return nil, errors.New("filter is given both as JSON and as bracket parameters")
+		}
+		b, err := unbracketFilter(q)
+		if err != nil {
+			return nil, err
+		}
+		filter = string(b)
+	}
 	if filter != "" {
-		if err := json.Unmarshal([]byte(filter), &d.Filter); err != nil {
+		if len(filter) > QUERY_MAX_FILTER_SIZE {
+			return nil, errors.New("filter is too large")
+		}
+		if jsonDepth(filter) > QUERY_MAX_FILTER_DEPTH {
+			return nil, errors.New("filter is nested too deeply")
+		}
+		f, err := parseFilter([]byte(filter))
+		if err != nil {
 			return nil, err
 		}
+		d.Filter = f
 	}
 	sort := q.Get("sort")
 	if sort != "" {
 		d.Sort = strings.Split(sort, ",")
+		if err := validateFieldList("sort", d.Sort, true); err != nil {
+			return nil, err
+		}
 	}
 
 	limit := q.Get("limit")
 	if limit != "" {
-		i, err := strconv.Atoi(limit)
+		// the default max limit applies, which rules out -1
+		i, err := parseBoundedInt("limit", limit, 0, ITEMS_MAX_LIMIT)
 		if err != nil {
 			return nil, err
 		}
@@ -248,7 +633,7 @@ func ParseQuery(q url.Values) (*DirectusQuery, error) {
 	}
 	offset := q.Get("offset")
 	if offset != "" {
-		i, err := strconv.Atoi(offset)
+		i, err := parseBoundedInt("offset", offset, 0, QUERY_MAX_OFFSET)
 		if err != nil {
 			return nil, err
 		}
@@ -257,7 +642,7 @@ func ParseQuery(q url.Values) (*DirectusQuery, error) {
 	}
 	page := q.Get("page")
 	if page != "" {
-		i, err := strconv.Atoi(page)
+		i, err := parseBoundedInt("page", page, 1, QUERY_MAX_OFFSET)
 		if err != nil {
 			return nil, err
 		}
@@ -271,6 +656,21 @@ func ParseQuery(q url.Values) (*DirectusQuery, error) {
 		}
 		d.Meta = &metaField
 	}
+	if err := parseAggregate(q, &d); err != nil {
+		return nil, err
+	}
+	if err := parseAlias(q, &d); err != nil {
+		return nil, err
+	}
+	if export := q.Get("export"); export != "" {
+		d.Export = FileFormat(export)
+		if !d.Export.valid() {
+			return nil, errors.New("invalid export format: " + strconv.Quote(export))
+		}
+	}
+	if err := parseVersion(q, &d); err != nil {
+		return nil, err
+	}
 
 	if err := d.validate(); err != nil {
 		return nil, err
@@ -278,25 +678,118 @@ func ParseQuery(q url.Values) (*DirectusQuery, error) {
 
 	return &d, nil
 }
+
+func parseBoundedInt(name string, s string, lower int, upper int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New(name + " must be an integer")
+	}
+	if i < lower || i > upper {
+		return 0, errors.New(name + " must be between " + strconv.Itoa(lower) + " and " + strconv.Itoa(upper))
+	}
+	return i, nil
+}
+
+func validateFieldList(name string, fields []string, sort bool) error {
+	if len(fields) > QUERY_MAX_FIELDS {
+		return errors.New("too many " + name)
+	}
+	for _, f := range fields {
+		if sort {
+			if f == SortRandom {
+				continue
+			}
+			f = strings.TrimPrefix(f, "-")
+		}
+		if !validFieldName(f) {
+			return errors.New("invalid " + name + " entry: " + strconv.Quote(f))
+		}
+	}
+	return nil
+}
+
+// validFieldName accepts dot separated paths of field names and wildcards,
+// e.g. "author.*" or "item:articles.title" for many-to-any fields, with
+// fields possibly wrapped in functions such as "year(date_published)".
+func validFieldName(f string) bool {
+	if f == "" || len(f) > QUERY_MAX_FIELD_LENGTH {
+		return false
+	}
+	for _, segment := range strings.Split(f, ".") {
+		if segment == "*" {
+			continue
+		}
+		segment, _ = unwrapFunction(segment)
+		if segment == "" {
+			return false
+		}
+		for i, c := range segment {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+			case c >= '0' && c <= '9', c == '-', c == ':':
+				if i == 0 {
+					return false
+				}
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// jsonDepth returns the maximum nesting of objects and arrays in s without
+// decoding it.
+func jsonDepth(s string) int {
+	depth, maxDepth := 0, 0
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return maxDepth
+}
+
 func (d *DirectusQuery) BuildQuery() (url.Values, error) {
 	v := url.Values{}
 	if len(d.Fields) > 0 {
 		v.Set("fields", strings.Join(d.Fields, ","))
 	}
-	if d.Filter != nil {
-		b, err := json.Marshal(d.Filter)
+	filter, sort := d.Filter, d.Sort
+	if d.cursor != nil {
+		filter, sort = d.cursorQuery()
+	}
+	if filter != nil {
+		b, err := currentCodec().Marshal(filterJSON(filter))
 		if err != nil {
 			return nil, err
 		}
 		v.Set("filter", string(b))
 	}
-	if len(d.Sort) > 0 {
-		v.Set("sort", strings.Join(d.Sort, ","))
+	if len(sort) > 0 {
+		v.Set("sort", strings.Join(sort, ","))
 	}
-	if d.Limit == 0 {
-		d.Limit = ITEMS_MAX_LIMIT
+	// BuildQuery must not write to d, queries are shared between goroutines
+	limit := d.Limit
+	if limit == 0 {
+		limit = ITEMS_MAX_LIMIT
 	}
-	v.Set("limit", strconv.Itoa(d.Limit))
+	v.Set("limit", strconv.Itoa(limit))
 	if d.offsetIsSet {
 		v.Set("offset", strconv.Itoa(d.Offset))
 	}
@@ -306,6 +799,12 @@ func (d *DirectusQuery) BuildQuery() (url.Values, error) {
 	if d.Meta != nil {
 		v.Set("meta", string(*d.Meta))
 	}
+	d.buildAggregate(v)
+	d.buildAlias(v)
+	if d.Export != "" {
+		v.Set("export", string(d.Export))
+	}
+	d.buildVersion(v)
 	return v, nil
 }
 
@@ -334,6 +833,20 @@ const (
 	OP_nbetween     FilterOperator = "_nbetween"
 	OP_empty        FilterOperator = "_empty"
 	OP_nempty       FilterOperator = "_nempty"
+
+	// Case-insensitive string operators.
+	OP_icontains     FilterOperator = "_icontains"
+	OP_nicontains    FilterOperator = "_nicontains"
+	OP_istarts_with  FilterOperator = "_istarts_with"
+	OP_nistarts_with FilterOperator = "_nistarts_with"
+	OP_iends_with    FilterOperator = "_iends_with"
+	OP_niends_with   FilterOperator = "_niends_with"
+
+	// Geospatial operators take a Geometry.
+	OP_intersects       FilterOperator = "_intersects"
+	OP_nintersects      FilterOperator = "_nintersects"
+	OP_intersects_bbox  FilterOperator = "_intersects_bbox"
+	OP_nintersects_bbox FilterOperator = "_nintersects_bbox"
 )
 
-type Filter map[string]map[FilterOperator]any
\ No newline at end of file
+type Filter map[string]map[FilterOperator]any
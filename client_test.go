@@ -1,116 +1,253 @@
 package directus_client
 
 import (
-	"github.com/go-chi/chi/v5"
-	"github.com/go-redis/redis/v8"
+	"context"
 	"github.com/stretchr/testify/require"
-	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
-	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
-	"time"
+
+	"gitlab.enkuchat.com/backend/directus_client/directustest"
 )
 
-func createClient(t *testing.T) *DirectusClient {
-	_url := os.Getenv("DIRECTUS_URL")
-	token := os.Getenv("DIRECTUS_TOKEN")
-	client, err := NewDirectusClient(_url, token, NewNoopQueryCache())
+type user struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+func createClient(t *testing.T, cache QueryCache) (*DirectusClient, *directustest.Server) {
+	srv := directustest.NewServer(t)
+	srv.LoadFixtures("testdata/user.json")
+	client, err := NewDirectusClient(srv.URL, directustest.Token, cache)
 	require.NoError(t, err)
-	return client
+	return client, srv
 }
 
 func TestDo(t *testing.T) {
-	client := createClient(t)
+	client, srv := createClient(t, NewNoopQueryCache())
 
 	resp, err := client.Query("GET", "user", DirectusQuery{
 		Fields: Fields{"id", "email"},
 		Filter: Filter{
 			"email": {
-				OP_eq: "dev@cloudswan.io",
+				OP_eq: "dev@dev.io",
 			},
 		},
 		// Sort: Fields{"id", "-email"},
 	}, nil)
-
 	require.NoError(t, err)
+	srv.AssertFields("user", "id", "email")
+	srv.AssertFilter("user", Filter{"email": {OP_eq: "dev@dev.io"}})
 
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
-	t.Logf("%s\n", body)
+	result := ReadResult[[]user](resp)
+	require.False(t, result.Err())
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
 
 	u, _ := url.Parse("https://localhost:8050/items/user?limit=100")
 	resp, err = client.Call(&http.Request{
 		Method: "GET",
 		URL:    u,
 	})
-	data, _ := io.ReadAll(resp.Body)
-	t.Logf("%s\n", data)
+	require.NoError(t, err)
+	result = ReadResult[[]user](resp)
+	require.False(t, result.Err())
+	require.Len(t, result.Data, 2)
 }
 
-func createDirectusClient() (*DirectusClient, error) {
-	var (
-		token      = os.Getenv("DIRECTUS_TOKEN")
-		redisAddrs = []string{
-			"localhost:6379",
-		}
-		redisDB          = 8
-		baseUrl          = "https://localhost:8050"
-		redisKeyspace    = "directus"
-		redisExecTimeout = time.Minute
-		redisCacheTTL    = time.Minute * 10
-		webhookAddr      = "127.0.0.1:9999"
-		webhookPath      = "/webhook"
-	)
-
-	wes, err := NewWebhookEventServer(webhookAddr, webhookPath)
-	if err != nil {
-		return nil, err
-	}
-
-	universalClient := redis.NewUniversalClient(&redis.UniversalOptions{
-		Addrs: redisAddrs,
-		DB:    redisDB,
-	})
+type memoryCacheService struct {
+	mu sync.Mutex
+	m  map[string][]byte
+}
 
-	cs, err := NewRedisCacheService(universalClient, RedisCacheServiceOption{
-		Keyspace:    redisKeyspace,
-		ExecTimeout: redisExecTimeout,
-		CacheTTL:    redisCacheTTL,
-	})
-	if err != nil {
-		return nil, err
-	}
-	cache, err := NewRefreshableQueryCache(cs, wes)
-	if err != nil {
-		return nil, err
+func (m *memoryCacheService) Get(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m[key], nil
+}
+func (m *memoryCacheService) Set(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m[key] = value
+	return nil
+}
+func (m *memoryCacheService) Del(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.m, key)
+	return nil
+}
+func (m *memoryCacheService) DelPrefix(ctx context.Context, prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.m {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.m, key)
+		}
 	}
-	return NewDirectusClient(baseUrl, token, cache)
+	return nil
+}
+func (m *memoryCacheService) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m = make(map[string][]byte)
+	return nil
 }
+
 func TestCache(t *testing.T) {
-	client, err := createDirectusClient()
+	wes, err := NewWebhookEventServer("127.0.0.1:0", "/webhook")
+	require.NoError(t, err)
+	defer wes.Shutdown()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, wes)
 	require.NoError(t, err)
+	client, srv := createClient(t, cache)
 
-	resp, err := client.Query("GET", "user", DirectusQuery{
+	query := DirectusQuery{
 		Fields: Fields{"id", "email"},
 		Filter: Filter{
 			"email": {
 				OP_eq: "dev@dev.io",
 			},
 		},
-		// Sort: Fields{"id", "-email"},
-	}, nil)
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err())
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+	require.Len(t, srv.Requests("user"), 1)
+}
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+// clearOnlyCacheService is a store that can't delete by prefix.
+type clearOnlyCacheService struct {
+	CacheService
 }
 
-func Benchmark(b *testing.B) {
-	client, err := createDirectusClient()
-	require.NoError(b, err)
+func TestPruneCollection(t *testing.T) {
+	for _, store := range []CacheService{
+		&memoryCacheService{m: make(map[string][]byte)},
+		clearOnlyCacheService{&memoryCacheService{m: make(map[string][]byte)}},
+	} {
+		cache, err := NewRefreshableQueryCache(store, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+		require.NoError(t, err)
+		q := cache.(*refreshableQueryCache)
+		require.NoError(t, q.Set("articles", "limit=1", []byte("a")))
+		require.NoError(t, q.Set("articles/1", "", []byte("b")))
+		require.NoError(t, q.Set("articles_tags", "limit=1", []byte("c")))
+
+		require.NoError(t, q.pruneCollection(context.Background(), "articles"))
+		for _, key := range [][2]string{{"articles", "limit=1"}, {"articles/1", ""}} {
+			b, _ := q.Get(key[0], key[1])
+			require.Empty(t, b)
+		}
+		b, _ := q.Get("articles_tags", "limit=1")
+		if _, ok := store.(PrefixCacheService); ok {
+			require.Equal(t, "c", string(b))
+		} else {
+			require.Empty(t, b)
+		}
+	}
+}
 
-	router := chi.NewRouter()
-	router.Handle("/forward/aaafff/*", client.Proxy(2))
+func TestWithToken(t *testing.T) {
+	var tokens []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "service", cache)
+	require.NoError(t, err)
+	var seen int32
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&seen, 1)
+			return next(req)
+		}
+	})
 
-	http.ListenAndServe(":9090", router)
-}
\ No newline at end of file
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	get := func(c *DirectusClient) {
+		resp, err := c.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+	}
+	get(client)
+	get(client)
+	// the user's requests neither hit nor fill the cache of the service
+	user := client.WithToken("user")
+	get(user)
+	get(user)
+	get(client)
+	require.Equal(t, []string{"service", "user", "user"}, tokens)
+	require.EqualValues(t, 5, atomic.LoadInt32(&seen))
+	require.NoError(t, user.Close())
+}
+
+func TestParseQueryLimits(t *testing.T) {
+	filter := `{"id":{"_eq":1}}`
+	for raw, wantErr := range map[string]string{
+		"fields=id,author.*,item:articles.title&filter=" + filter:                        "",
+		"fields=id,a%20b&filter=" + filter:                                               `invalid fields entry: "a b"`,
+		"fields=id,.title&filter=" + filter:                                              `invalid fields entry: ".title"`,
+		"sort=-date_created,id&filter=" + filter:                                         "",
+		"sort=--id&filter=" + filter:                                                     `invalid sort entry: "-id"`,
+		"limit=-5&filter=" + filter:                                                      "limit must be between 0 and 1000",
+		"limit=-1&filter=" + filter:                                                      "limit must be between 0 and 1000",
+		"limit=99999999999999999999&filter=" + filter:                                    "limit must be an integer",
+		"offset=-1&filter=" + filter:                                                     "offset must be between 0 and 16777216",
+		"page=0&filter=" + filter:                                                        "page must be between 1 and 16777216",
+		"filter=" + strings.Repeat("[", 100) + strings.Repeat("]", 100):                  "filter is nested too deeply",
+		"filter=" + `{"id%3B drop":{"_eq":1}}`:                                           `invalid filter field: "id; drop"`,
+		"filter=" + `{"x":{"_eq":"` + strings.Repeat("a", QUERY_MAX_FILTER_SIZE) + `"}}`: "filter is too large",
+	} {
+		q, err := url.ParseQuery(raw)
+		require.NoError(t, err)
+		_, err = ParseQuery(q)
+		if wantErr == "" {
+			require.NoError(t, err, raw)
+		} else {
+			require.EqualError(t, err, wantErr, raw)
+		}
+	}
+}
+
+func FuzzParseQuery(f *testing.F) {
+	for _, seed := range []string{
+		`fields=id,email&filter={"email":{"_eq":"dev@dev.io"}}&limit=10&offset=5&meta=*`,
+		`sort=-id&filter={"id":{"_in":[1,2,3]}}&page=2`,
+		`filter={"a":{"_eq":"\"}]"}}`,
+		`limit=1000&filter={}`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, raw string) {
+		q, err := url.ParseQuery(raw)
+		if err != nil {
+			return
+		}
+		d, err := ParseQuery(q)
+		if err != nil {
+			return
+		}
+		v, err := d.BuildQuery()
+		if err != nil {
+			t.Fatalf("BuildQuery after ParseQuery(%q): %v", raw, err)
+		}
+		if _, err := ParseQuery(v); err != nil {
+			t.Fatalf("ParseQuery(BuildQuery(%q)) = %v", raw, err)
+		}
+	})
+}
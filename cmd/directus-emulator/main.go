@@ -0,0 +1,33 @@
+// Command directus-emulator serves an in-memory Directus /items API seeded
+// from JSON files, for running apps built on directus_client offline:
+//
+//	directus-emulator -addr :8055 -token dev -seed ./seed
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func main() {
+	var (
+		addr  = flag.String("addr", ":8055", "listen address")
+		token = flag.String("token", "", "static token required by requests, none if empty")
+		seed  = flag.String("seed", "", "seed file or directory of <collection>.json files")
+	)
+	flag.Parse()
+
+	e := emulator.New(*token)
+	if *seed != "" {
+		if err := e.LoadSeed(*seed); err != nil {
+			log.Fatal().Err(err).Msg("failed to load seed")
+		}
+	}
+	log.Info().Msg("directus emulator listening on " + *addr)
+	if err := http.ListenAndServe(*addr, e); err != nil {
+		log.Fatal().Err(err).Msg("emulator stopped")
+	}
+}
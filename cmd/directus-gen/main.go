@@ -0,0 +1,64 @@
+// Command directus-gen generates Go structs for the collections of a Directus
+// project, either from a running instance or from a schema snapshot:
+//
+//	//go:generate go run gitlab.enkuchat.com/backend/directus_client/cmd/directus-gen -snapshot schema.json -o models_gen.go -pkg models
+//
+// Without -snapshot, DIRECTUS_URL and DIRECTUS_TOKEN (or -url and -token) are
+// used to read /collections, /fields and /relations.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"gitlab.enkuchat.com/backend/directus_client/codegen"
+)
+
+func main() {
+	var (
+		snapshot = flag.String("snapshot", "", "schema snapshot file (JSON)")
+		baseURL  = flag.String("url", os.Getenv("DIRECTUS_URL"), "directus base url")
+		token    = flag.String("token", os.Getenv("DIRECTUS_TOKEN"), "directus token")
+		output   = flag.String("o", "", "output file, stdout if empty")
+		pkg      = flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+		system   = flag.Bool("system", false, "include directus_* system collections")
+	)
+	flag.Parse()
+
+	if err := run(*snapshot, *baseURL, *token, *output, codegen.Options{
+		Package:       *pkg,
+		IncludeSystem: *system,
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, "directus-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(snapshot, baseURL, token, output string, opt codegen.Options) error {
+	var (
+		schema *codegen.Schema
+		err    error
+	)
+	if snapshot != "" {
+		schema, err = codegen.LoadSnapshot(snapshot)
+	} else {
+		if baseURL == "" || token == "" {
+			return fmt.Errorf("either -snapshot or -url and -token are required")
+		}
+		schema, err = codegen.FetchSchema(baseURL, token)
+	}
+	if err != nil {
+		return err
+	}
+
+	src, err := codegen.Generate(schema, opt)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(output, src, 0644)
+}
@@ -0,0 +1,57 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"io"
+	"sync/atomic"
+)
+
+// Codec marshals JSON for the package: ReadResult, BuildQuery, ParseQuery, the
+// cache envelope and webhook payloads all go through it. Drop-in encoders
+// such as jsoniter.ConfigCompatibleWithStandardLibrary or sonic.ConfigStd
+// implement it as is.
+//
+// The data passed to Unmarshal is reused once it returns, so a codec must not
+// keep references to it.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type stdCodec struct{}
+
+func (stdCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (stdCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// codecHolder keeps atomic.Value storing a single concrete type.
+type codecHolder struct{ Codec }
+
+var codec atomic.Value
+
+func init() {
+	codec.Store(codecHolder{stdCodec{}})
+}
+
+// SetCodec replaces encoding/json for all clients. It is meant to be called
+// once during start up; nil restores encoding/json.
+func SetCodec(c Codec) {
+	if c == nil {
+		c = stdCodec{}
+	}
+	codec.Store(codecHolder{c})
+}
+
+func currentCodec() Codec {
+	return codec.Load().(codecHolder).Codec
+}
+
+// decodeJSON reads r into a pooled buffer and unmarshals it with the current
+// codec, which is faster than streaming for the alternative codecs.
+func decodeJSON(r io.Reader, v any) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(r); err != nil {
+		return err
+	}
+	return currentCodec().Unmarshal(buf.Bytes(), v)
+}
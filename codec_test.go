@@ -0,0 +1,49 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingCodec struct {
+	marshal, unmarshal int64
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	atomic.AddInt64(&c.marshal, 1)
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	atomic.AddInt64(&c.unmarshal, 1)
+	return json.Unmarshal(data, v)
+}
+
+func TestSetCodec(t *testing.T) {
+	c := new(countingCodec)
+	SetCodec(c)
+	defer SetCodec(nil)
+
+	client, _ := createClient(t, NewNoopQueryCache())
+	resp, err := client.Query("GET", "user", DirectusQuery{
+		Fields: Fields{"id", "email"},
+		Filter: Filter{"email": {OP_eq: "dev@dev.io"}},
+	}, nil)
+	require.NoError(t, err)
+	result := ReadResult[[]user](resp)
+	require.False(t, result.Err())
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	require.EqualValues(t, 1, atomic.LoadInt64(&c.marshal))
+	require.EqualValues(t, 1, atomic.LoadInt64(&c.unmarshal))
+
+	_, err = ParseQuery(url.Values{"filter": {`{"id":{"_eq":1}}`}})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt64(&c.unmarshal))
+
+	SetCodec(nil)
+	require.Equal(t, stdCodec{}, currentCodec())
+}
@@ -0,0 +1,302 @@
+// Package codegen generates Go models from a Directus schema. It is used by
+// cmd/directus-gen and can be driven by go:generate.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const clientImport = "gitlab.enkuchat.com/backend/directus_client"
+
+type Options struct {
+	// Package is the package name of the generated file.
+	Package string
+	// IncludeSystem also generates directus_* collections.
+	IncludeSystem bool
+}
+
+type model struct {
+	Package       string
+	Imports       []string
+	ImportsClient bool
+	Structs       []structDef
+}
+
+type structDef struct {
+	Name       string
+	Collection string
+	Note       string
+	Fields     []fieldDef
+}
+
+type fieldDef struct {
+	Name  string
+	Field string
+	Type  string
+	Tag   string
+}
+
+// Generate renders a gofmt-ed Go file declaring one struct per collection,
+// plus <Name>Collection and <Name>Fields constants so queries can refer to
+// schema names through the compiler.
+func Generate(s *Schema, opt Options) ([]byte, error) {
+	if opt.Package == "" {
+		opt.Package = "models"
+	}
+	m, err := buildModel(s, opt)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := fileTemplate.Execute(buf, m); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func buildModel(s *Schema, opt Options) (*model, error) {
+	m := &model{Package: opt.Package}
+	names := make(map[string]string)
+	// declared maps the package level identifiers to their collection
+	declared := make(map[string]string)
+	for _, c := range s.Collections {
+		// folders have no table
+		if c.Schema == nil {
+			continue
+		}
+		if !opt.IncludeSystem && strings.HasPrefix(c.Collection, "directus_") {
+			continue
+		}
+		name := exportedName(c.Collection)
+		for _, ident := range []string{name, name + "Collection", name + "Fields"} {
+			if other, ok := declared[ident]; ok {
+				return nil, fmt.Errorf("codegen: collections %q and %q both declare %s", other, c.Collection, ident)
+			}
+			declared[ident] = c.Collection
+		}
+		names[c.Collection] = name
+	}
+
+	imports := make(map[string]struct{})
+	for _, c := range s.Collections {
+		name, ok := names[c.Collection]
+		if !ok {
+			continue
+		}
+		sd := structDef{Name: name, Collection: c.Collection}
+		fields := make(map[string]string)
+		if c.Meta != nil && c.Meta.Note != nil {
+			sd.Note = *c.Meta.Note
+		}
+		for _, f := range s.Fields {
+			if f.Collection != c.Collection {
+				continue
+			}
+			typ, omitempty := fieldType(s, f, names)
+			if typ == "" {
+				continue
+			}
+			tag := f.Field
+			if omitempty {
+				tag += ",omitempty"
+			}
+			for pkg, prefix := range map[string]string{
+				"encoding/json": "json.",
+				"time":          "time.",
+				clientImport:    "directus.",
+			} {
+				if strings.Contains(typ, prefix) {
+					imports[pkg] = struct{}{}
+				}
+			}
+			fieldName := exportedName(f.Field)
+			if other, ok := fields[fieldName]; ok {
+				return nil, fmt.Errorf("codegen: fields %q and %q of %s both map to %s", other, f.Field, c.Collection, fieldName)
+			}
+			fields[fieldName] = f.Field
+			sd.Fields = append(sd.Fields, fieldDef{
+				Name:  fieldName,
+				Field: f.Field,
+				Type:  typ,
+				Tag:   "`json:\"" + tag + "\"`",
+			})
+		}
+		m.Structs = append(m.Structs, sd)
+	}
+	sort.Slice(m.Structs, func(i, j int) bool { return m.Structs[i].Name < m.Structs[j].Name })
+	for pkg := range imports {
+		if pkg == clientImport {
+			m.ImportsClient = true
+			continue
+		}
+		m.Imports = append(m.Imports, pkg)
+	}
+	sort.Strings(m.Imports)
+	return m, nil
+}
+
+// fieldType maps a field to its Go type. Alias fields that are not relations
+// hold no data and yield "".
+func fieldType(s *Schema, f Field, names map[string]string) (typ string, omitempty bool) {
+	if r := findRelation(s, f); r != nil {
+		related := "map[string]any"
+		if r.many {
+			if n, ok := names[r.collection]; ok {
+				related = n
+			}
+			return "[]directus.Relation[" + related + "]", true
+		}
+		if n, ok := names[r.collection]; ok {
+			related = n
+		}
+		return "*directus.Relation[" + related + "]", true
+	}
+
+	special := make(map[string]bool)
+	if f.Meta != nil {
+		for _, s := range f.Meta.Special {
+			special[s] = true
+		}
+	}
+	switch {
+	case special["cast-csv"] || f.Type == "csv":
+		return "[]string", false
+	case special["cast-json"] || f.Type == "json":
+		return "json.RawMessage", false
+	}
+
+	switch f.Type {
+	case "alias":
+		return "", false
+	case "string", "text", "uuid", "hash", "date", "time", "dateTime", "binary":
+		typ = "string"
+	case "integer":
+		typ = "int"
+	case "bigInteger":
+		typ = "int64"
+	case "float":
+		typ = "float64"
+	case "decimal":
+		// decimals are returned as strings to keep their precision
+		typ = "json.Number"
+	case "boolean":
+		typ = "bool"
+	case "timestamp":
+		typ = "time.Time"
+	default:
+		// geometry types and anything newer than this generator
+		return "json.RawMessage", false
+	}
+	if f.Schema != nil && f.Schema.IsNullable && !f.Schema.IsPrimaryKey {
+		typ = "*" + typ
+	}
+	return typ, false
+}
+
+type relationTarget struct {
+	collection string
+	many       bool
+}
+
+func findRelation(s *Schema, f Field) *relationTarget {
+	for _, r := range s.Relations {
+		// many-to-one: the field holds the foreign key
+		if r.Collection == f.Collection && r.Field == f.Field {
+			t := &relationTarget{}
+			if r.RelatedCollection != nil {
+				t.collection = *r.RelatedCollection
+			}
+			return t
+		}
+		// one-to-many (and the o2m side of m2m/m2a/translations): an alias
+		// listing the rows of r.Collection pointing back at this item
+		if f.Type == "alias" && r.RelatedCollection != nil && *r.RelatedCollection == f.Collection &&
+			r.Meta != nil && r.Meta.OneField != nil && *r.Meta.OneField == f.Field {
+			return &relationTarget{collection: r.Collection, many: true}
+		}
+	}
+	return nil
+}
+
+var initialisms = map[string]string{
+	"id":   "ID",
+	"uuid": "UUID",
+	"url":  "URL",
+	"uri":  "URI",
+	"api":  "API",
+	"ip":   "IP",
+	"html": "HTML",
+	"json": "JSON",
+	"seo":  "SEO",
+}
+
+// exportedName converts snake_case and kebab-case names to an
+// exported Go identifier.
+func exportedName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	b := new(strings.Builder)
+	for _, w := range words {
+		if i, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(i)
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// comment prefixes every line of s with "// ".
+func comment(s string) string {
+	return "// " + strings.ReplaceAll(s, "\n", "\n// ")
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{"comment": comment}).Parse(`// Code generated by directus-gen. DO NOT EDIT.
+
+package {{.Package}}
+{{if or .Imports .ImportsClient}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+{{- if .ImportsClient}}
+{{if .Imports}}
+{{end}}	directus "` + clientImport + `"
+{{- end}}
+)
+{{end}}
+{{- range .Structs}}
+// {{.Name}} is an item of the {{.Collection}} collection.{{if .Note}}
+{{comment .Note}}{{end}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+
+const {{.Name}}Collection = "{{.Collection}}"
+
+// {{.Name}}Fields holds the field names of the {{.Collection}} collection.
+var {{.Name}}Fields = struct {
+{{- range .Fields}}
+	{{.Name}} string
+{{- end}}
+}{
+{{- range .Fields}}
+	{{.Name}}: "{{.Field}}",
+{{- end}}
+}
+{{end}}`))
@@ -0,0 +1,80 @@
+package codegen
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	schema, err := LoadSnapshot("testdata/snapshot.json")
+	require.NoError(t, err)
+
+	src, err := Generate(schema, Options{Package: "models"})
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile("testdata/models.golden", src, 0644))
+	}
+	golden, err := os.ReadFile("testdata/models.golden")
+	require.NoError(t, err)
+	require.Equal(t, string(golden), string(src))
+}
+
+func TestExportedName(t *testing.T) {
+	for in, want := range map[string]string{
+		"id":           "ID",
+		"date_created": "DateCreated",
+		"seo-meta":     "SEOMeta",
+		"imageUrl":     "ImageUrl",
+		"2fa":          "X2fa",
+	} {
+		require.Equal(t, want, exportedName(in), in)
+	}
+}
+
+func TestGenerateMultilineNote(t *testing.T) {
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"collections": [{"collection": "posts", "meta": {"note": "Blog posts.\nOne per slug."}, "schema": {"name": "posts"}}],
+		"fields": [{"collection": "posts", "field": "id", "type": "integer"}]
+	}`), &schema))
+
+	src, err := Generate(&schema, Options{})
+	require.NoError(t, err)
+	require.Contains(t, string(src), "// Posts is an item of the posts collection.\n// Blog posts.\n// One per slug.\ntype Posts struct")
+}
+
+func TestGenerateNameCollisions(t *testing.T) {
+	for name, snapshot := range map[string]string{
+		"collections": `{
+			"collections": [
+				{"collection": "blog_post", "schema": {"name": "blog_post"}},
+				{"collection": "blog-post", "schema": {"name": "blog-post"}}
+			]
+		}`,
+		"constants": `{
+			"collections": [
+				{"collection": "blog", "schema": {"name": "blog"}},
+				{"collection": "blog_fields", "schema": {"name": "blog_fields"}}
+			]
+		}`,
+		"fields": `{
+			"collections": [{"collection": "posts", "schema": {"name": "posts"}}],
+			"fields": [
+				{"collection": "posts", "field": "date_created", "type": "timestamp"},
+				{"collection": "posts", "field": "date-created", "type": "timestamp"}
+			]
+		}`,
+	} {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(snapshot), &schema), name)
+		_, err := Generate(&schema, Options{})
+		require.Error(t, err, name)
+	}
+}
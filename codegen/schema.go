@@ -0,0 +1,103 @@
+package codegen
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Schema is the data model of a Directus project, as returned by /collections,
+// /fields and /relations or stored in a `directus schema snapshot` file.
+type Schema struct {
+	Collections []Collection `json:"collections"`
+	Fields      []Field      `json:"fields"`
+	Relations   []Relation   `json:"relations"`
+}
+
+type Collection struct {
+	Collection string `json:"collection"`
+	Meta       *struct {
+		Note      *string `json:"note"`
+		Singleton bool    `json:"singleton"`
+	} `json:"meta"`
+	Schema *struct {
+		Name string `json:"name"`
+	} `json:"schema"`
+}
+
+type Field struct {
+	Collection string `json:"collection"`
+	Field      string `json:"field"`
+	Type       string `json:"type"`
+	Meta       *struct {
+		Special []string `json:"special"`
+		Note    *string  `json:"note"`
+	} `json:"meta"`
+	Schema *struct {
+		IsNullable   bool `json:"is_nullable"`
+		IsPrimaryKey bool `json:"is_primary_key"`
+	} `json:"schema"`
+}
+
+type Relation struct {
+	Collection        string  `json:"collection"`
+	Field             string  `json:"field"`
+	RelatedCollection *string `json:"related_collection"`
+	Meta              *struct {
+		OneField      *string `json:"one_field"`
+		JunctionField *string `json:"junction_field"`
+	} `json:"meta"`
+}
+
+// LoadSnapshot reads a schema snapshot in JSON format.
+func LoadSnapshot(path string) (*Schema, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// FetchSchema reads the schema from a running Directus instance. The token
+// needs read access to the system collections.
+func FetchSchema(baseURL string, token string) (*Schema, error) {
+	client := &http.Client{Timeout: time.Second * 30}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	var s Schema
+	if err := fetch(client, baseURL+"/collections", token, &s.Collections); err != nil {
+		return nil, err
+	}
+	if err := fetch(client, baseURL+"/fields", token, &s.Fields); err != nil {
+		return nil, err
+	}
+	if err := fetch(client, baseURL+"/relations", token, &s.Relations); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func fetch(client *http.Client, url string, token string, data any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(url + ": " + resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(&struct {
+		Data any `json:"data"`
+	}{data})
+}
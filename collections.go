@@ -0,0 +1,52 @@
+package directus_client
+
+import "encoding/json"
+
+// Collection is a collection of the Directus data model. Schema is nil for
+// folders, collections without a database table that only group others in
+// the app. Fields is only sent on creation, e.g. to set up the primary key;
+// Directus adds an integer id otherwise.
+type Collection struct {
+	Collection string            `json:"collection"`
+	Meta       *CollectionMeta   `json:"meta,omitempty"`
+	Schema     *CollectionSchema `json:"schema,omitempty"`
+	Fields     []CollectionField `json:"fields,omitempty"`
+}
+
+// CollectionMeta is how Directus presents and handles a collection.
+// ArchiveValue and UnarchiveValue are strings in Directus whatever the type
+// of ArchiveField.
+type CollectionMeta struct {
+	Icon                  string          `json:"icon,omitempty"`
+	Note                  string          `json:"note,omitempty"`
+	Color                 string          `json:"color,omitempty"`
+	DisplayTemplate       string          `json:"display_template,omitempty"`
+	Hidden                bool            `json:"hidden,omitempty"`
+	Singleton             bool            `json:"singleton,omitempty"`
+	Translations          json.RawMessage `json:"translations,omitempty"`
+	SortField             string          `json:"sort_field,omitempty"`
+	ArchiveField          string          `json:"archive_field,omitempty"`
+	ArchiveValue          string          `json:"archive_value,omitempty"`
+	UnarchiveValue        string          `json:"unarchive_value,omitempty"`
+	ArchiveAppFilter      bool            `json:"archive_app_filter,omitempty"`
+	Accountability        string          `json:"accountability,omitempty"`
+	ItemDuplicationFields []string        `json:"item_duplication_fields,omitempty"`
+	Sort                  int             `json:"sort,omitempty"`
+	Group                 string          `json:"group,omitempty"`
+	Collapse              string          `json:"collapse,omitempty"`
+	PreviewURL            string          `json:"preview_url,omitempty"`
+	Versioning            bool            `json:"versioning,omitempty"`
+}
+
+// CollectionSchema describes the database table of a collection.
+type CollectionSchema struct {
+	Name    string `json:"name,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Collections returns a client for the collections of Directus at
+// /collections, keyed by collection name. Deleting a collection drops its
+// table and all its items.
+func (d *DirectusClient) Collections() *SystemClient[Collection] {
+	return System[Collection](d, "/collections")
+}
@@ -0,0 +1,74 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollections(t *testing.T) {
+	collections := map[string]map[string]any{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /collections":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			collections[body["collection"].(string)] = body
+			json.NewEncoder(w).Encode(map[string]any{"data": body})
+		case "GET /collections/articles":
+			if collections["articles"] == nil {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": collections["articles"]})
+		case "PATCH /collections/articles":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			meta := collections["articles"]["meta"].(map[string]any)
+			for k, v := range body["meta"].(map[string]any) {
+				meta[k] = v
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": collections["articles"]})
+		case "DELETE /collections/articles":
+			delete(collections, "articles")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	created, err := client.Collections().Create(ctx, Collection{
+		Collection: "articles",
+		Meta:       &CollectionMeta{Icon: "article", Note: "Blog posts"},
+		Schema:     &CollectionSchema{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "article", created.Meta.Icon)
+	require.Equal(t, map[string]any{
+		"collection": "articles",
+		"meta":       map[string]any{"icon": "article", "note": "Blog posts"},
+		"schema":     map[string]any{},
+	}, collections["articles"])
+
+	updated, err := client.Collections().Update(ctx, "articles", Collection{Meta: &CollectionMeta{SortField: "sort"}})
+	require.NoError(t, err)
+	require.Equal(t, &CollectionMeta{Icon: "article", Note: "Blog posts", SortField: "sort"}, updated.Meta)
+
+	got, err := client.Collections().Get(ctx, "articles")
+	require.NoError(t, err)
+	require.Equal(t, "sort", got.Meta.SortField)
+	require.NotNil(t, got.Schema)
+
+	require.NoError(t, client.Collections().Delete(ctx, "articles"))
+	_, err = client.Collections().Get(ctx, "articles")
+	require.Error(t, err)
+}
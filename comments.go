@@ -0,0 +1,124 @@
+package directus_client
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Comment is a comment on an item. Directus 10 records comments as activity,
+// see ActivityComments; their ids are those of the activity.
+type Comment struct {
+	ID          string     `json:"id,omitempty"`
+	Collection  string     `json:"collection,omitempty"`
+	Item        string     `json:"item,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	DateCreated *time.Time `json:"date_created,omitempty"`
+	DateUpdated *time.Time `json:"date_updated,omitempty"`
+	UserCreated string     `json:"user_created,omitempty"`
+	UserUpdated string     `json:"user_updated,omitempty"`
+}
+
+// CommentsClient manages the comments on items.
+type CommentsClient struct {
+	d *DirectusClient
+	// activity selects the /activity/comment endpoints of Directus 10
+	activity bool
+}
+
+// Comments returns a client for the comments of Directus 11 at /comments.
+func (d *DirectusClient) Comments() *CommentsClient {
+	return &CommentsClient{d: d}
+}
+
+// ActivityComments returns a client for the comments of Directus 10, which
+// are activity with the action "comment" written at /activity/comment.
+func (d *DirectusClient) ActivityComments() *CommentsClient {
+	return &CommentsClient{d: d, activity: true}
+}
+
+// List returns the comments on item of collection, oldest first; at most
+// as many as the maximum limit of d.
+func (c *CommentsClient) List(ctx context.Context, collection string, item any) ([]Comment, error) {
+	key, err := formatKey(item)
+	if err != nil {
+		return nil, err
+	}
+	f := Filter{"collection": {OP_eq: collection}, "item": {OP_eq: key}}
+	if c.activity {
+		f["action"] = map[FilterOperator]any{OP_eq: ActivityComment}
+		activities, _, err := c.d.Activity().List(ctx, DirectusQuery{Filter: f, Sort: Fields{"id"}, Limit: c.d.maxLimit})
+		if err != nil {
+			return nil, err
+		}
+		comments := make([]Comment, len(activities))
+		for i, a := range activities {
+			comments[i] = activityComment(a)
+		}
+		return comments, nil
+	}
+	comments, _, err := System[Comment](c.d, "/comments").List(ctx, DirectusQuery{Filter: f, Sort: Fields{"date_created"}, Limit: c.d.maxLimit})
+	return comments, err
+}
+
+// Create comments comment on item of collection and returns the comment.
+func (c *CommentsClient) Create(ctx context.Context, collection string, item any, comment string) (Comment, error) {
+	key, err := formatKey(item)
+	if err != nil {
+		return Comment{}, err
+	}
+	return c.send(ctx, "POST", c.path(), map[string]string{"collection": collection, "item": key, "comment": comment})
+}
+
+// Update replaces the text of the comment with id and returns the comment.
+func (c *CommentsClient) Update(ctx context.Context, id string, comment string) (Comment, error) {
+	path, err := systemPath(c.path(), id)
+	if err != nil {
+		return Comment{}, err
+	}
+	return c.send(ctx, "PATCH", path, map[string]string{"comment": comment})
+}
+
+// Delete deletes the comment with id.
+func (c *CommentsClient) Delete(ctx context.Context, id string) error {
+	path, err := systemPath(c.path(), id)
+	if err != nil {
+		return err
+	}
+	return c.d.sendJSON(ctx, "DELETE", path, nil, nil, nil)
+}
+
+func (c *CommentsClient) path() string {
+	if c.activity {
+		return "/activity/comment"
+	}
+	return "/comments"
+}
+
+// send sends body to path and decodes the comment, or the activity of the
+// comment, of the response.
+func (c *CommentsClient) send(ctx context.Context, method string, path string, body any) (Comment, error) {
+	if c.activity {
+		var a Activity
+		err := c.d.sendJSON(ctx, method, path, nil, body, &a)
+		return activityComment(a), err
+	}
+	var comment Comment
+	err := c.d.sendJSON(ctx, method, path, nil, body, &comment)
+	return comment, err
+}
+
+// activityComment returns the comment recorded as activity a.
+func activityComment(a Activity) Comment {
+	c := Comment{
+		Collection:  a.Collection,
+		Item:        a.Item,
+		Comment:     a.Comment,
+		DateCreated: a.Timestamp,
+		UserCreated: a.User,
+	}
+	if a.ID != 0 {
+		c.ID = strconv.Itoa(a.ID)
+	}
+	return c
+}
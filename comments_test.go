@@ -0,0 +1,98 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComments(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		comments func(*DirectusClient) *CommentsClient
+		path     string
+		list     string
+		id       any
+		filter   string
+		record   func(id any, comment string) map[string]any
+	}{
+		{
+			name:     "Directus 11",
+			comments: (*DirectusClient).Comments,
+			path:     "/comments",
+			list:     "/comments",
+			id:       "c1",
+			filter:   `{"collection":{"_eq":"articles"},"item":{"_eq":"5"}}`,
+			record: func(id any, comment string) map[string]any {
+				return map[string]any{"id": id, "collection": "articles", "item": "5", "comment": comment, "user_created": "bot", "date_created": "2024-05-01T10:00:00Z"}
+			},
+		},
+		{
+			name:     "Directus 10",
+			comments: (*DirectusClient).ActivityComments,
+			path:     "/activity/comment",
+			list:     "/activity",
+			id:       7,
+			filter:   `{"action":{"_eq":"comment"},"collection":{"_eq":"articles"},"item":{"_eq":"5"}}`,
+			record: func(id any, comment string) map[string]any {
+				return map[string]any{"id": id, "action": "comment", "collection": "articles", "item": "5", "comment": comment, "user": "bot", "timestamp": "2024-05-01T10:00:00Z"}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			text := ""
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case "GET":
+					require.Equal(t, tt.filter, r.URL.Query().Get("filter"))
+					json.NewEncoder(w).Encode(map[string]any{"data": []any{tt.record(tt.id, text)}})
+				case "POST", "PATCH":
+					var body map[string]string
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					if r.Method == "POST" {
+						require.Equal(t, "articles", body["collection"])
+						require.Equal(t, "5", body["item"])
+					}
+					text = body["comment"]
+					json.NewEncoder(w).Encode(map[string]any{"data": tt.record(tt.id, text)})
+				case "DELETE":
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer srv.Close()
+			client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+			require.NoError(t, err)
+			ctx := context.Background()
+			comments := tt.comments(client)
+
+			created, err := comments.Create(ctx, "articles", 5, "Needs a better title")
+			require.NoError(t, err)
+			require.Equal(t, "Needs a better title", created.Comment)
+			require.Equal(t, "bot", created.UserCreated)
+			require.NotNil(t, created.DateCreated)
+
+			updated, err := comments.Update(ctx, created.ID, "Looks good now")
+			require.NoError(t, err)
+			require.Equal(t, "Looks good now", updated.Comment)
+
+			list, err := comments.List(ctx, "articles", 5)
+			require.NoError(t, err)
+			require.Equal(t, []Comment{updated}, list)
+
+			require.NoError(t, comments.Delete(ctx, created.ID))
+			require.Equal(t, []string{
+				"POST " + tt.path,
+				"PATCH " + tt.path + "/" + created.ID,
+				"GET " + tt.list,
+				"DELETE " + tt.path + "/" + created.ID,
+			}, requests)
+		})
+	}
+}
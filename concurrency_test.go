@@ -0,0 +1,76 @@
+package directus_client
+
+import (
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+// The tests in this file document the concurrency contract of the package and
+// are meant to be run with -race.
+
+func TestConcurrentQuery(t *testing.T) {
+	wes, err := NewWebhookEventServer("127.0.0.1:0", "/webhook")
+	require.NoError(t, err)
+	defer wes.Shutdown()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, wes)
+	require.NoError(t, err)
+	client, _ := createClient(t, cache)
+
+	// one query value shared by all goroutines, BuildQuery must not modify it
+	query := DirectusQuery{
+		Fields: Fields{"id", "email"},
+		Filter: Filter{"email": {OP_eq: "dev@dev.io"}},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				resp, err := client.Query("GET", "user", query, nil)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				result := ReadResult[[]user](resp)
+				if result.Err() || len(result.Data) != 1 {
+					t.Errorf("unexpected result %+v", result)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBuildQueryDoesNotMutate(t *testing.T) {
+	query := &DirectusQuery{Fields: Fields{"id"}}
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "1000", v.Get("limit"))
+	require.Zero(t, query.Limit)
+}
+
+func TestConcurrentObservers(t *testing.T) {
+	wes, err := NewWebhookEventServer("127.0.0.1:0", "/webhook")
+	require.NoError(t, err)
+	defer wes.Shutdown()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			collection := "c" + string(rune('a'+i))
+			for j := 0; j < 100; j++ {
+				if err := wes.AddObserver(collection, func(WebhookEvent) {}); err != nil {
+					t.Error(err)
+					return
+				}
+				wes.RemoveObserver(collection)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
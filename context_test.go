@@ -0,0 +1,106 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+// contextCache records the context values it is called with.
+type contextCache struct {
+	memoryCacheService
+	values []any
+}
+
+func (c *contextCache) GetContext(ctx context.Context, key string) ([]byte, error) {
+	c.record(ctx)
+	return c.Get(key)
+}
+func (c *contextCache) SetContext(ctx context.Context, key string, value []byte) error {
+	c.record(ctx)
+	return c.Set(key, value)
+}
+func (c *contextCache) DelContext(ctx context.Context, key string) error {
+	c.record(ctx)
+	return c.Del(key)
+}
+func (c *contextCache) record(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values = append(c.values, ctx.Value(ctxKey{}))
+}
+
+func TestQueryContext(t *testing.T) {
+	wes, err := NewWebhookEventServer("127.0.0.1:0", "/webhook")
+	require.NoError(t, err)
+	defer wes.Shutdown()
+	store := &contextCache{memoryCacheService: memoryCacheService{m: make(map[string][]byte)}}
+	cache, err := NewRefreshableQueryCache(store, wes)
+	require.NoError(t, err)
+	client, _ := createClient(t, cache)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "trace")
+	query := DirectusQuery{
+		Fields: Fields{"id", "email"},
+		Filter: Filter{"email": {OP_eq: "dev@dev.io"}},
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.QueryContext(ctx, "GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+	// get, set, get
+	require.Equal(t, []any{"trace", "trace", "trace"}, store.values)
+}
+
+func TestCallContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest("GET", srv.URL+"/items/user", nil)
+	_, err = client.CallContext(ctx, req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestObserverContext(t *testing.T) {
+	wes, err := NewWebhookEventServer("127.0.0.1:0", "/webhook")
+	require.NoError(t, err)
+
+	var (
+		mu  sync.Mutex
+		got context.Context
+	)
+	require.NoError(t, wes.AddObserverContext("user", func(ctx context.Context, we WebhookEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = ctx
+	}))
+	resp, err := http.Post("http://"+wes.Addr().String()+"/webhook", "application/json", strings.NewReader(`{"event":"items.update","collection":"user","key":"1"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return got != nil
+	}, 3*time.Second, 10*time.Millisecond)
+	require.NoError(t, got.Err())
+	require.NoError(t, wes.Shutdown())
+	require.ErrorIs(t, got.Err(), context.Canceled)
+}
@@ -0,0 +1,41 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Count returns the number of items of collection matching filter, all of
+// them if filter is nil, from the filter_count of a query for no items.
+func (d *DirectusClient) Count(ctx context.Context, collection string, filter FilterNode) (int, error) {
+	meta := MetaQueryFilterCount
+	query := DirectusQuery{Filter: filter, Meta: &meta}
+	if err := d.checkQuery(&query); err != nil {
+		return 0, err
+	}
+	v, err := query.BuildQuery()
+	if err != nil {
+		return 0, err
+	}
+	// BuildQuery reads a limit of 0 as unset, Directus returns no items for it
+	v.Set("limit", "0")
+	if d.bracketFilters {
+		if err := bracketFilter(v); err != nil {
+			return 0, err
+		}
+	}
+	resp, err := d.CallContext(ctx, d.newItemsRequest(ctx, http.MethodGet, collection, v, nil))
+	if err != nil {
+		return 0, err
+	}
+	var counts Meta
+	if err := readItems(resp, nil, &counts); err != nil {
+		return 0, err
+	}
+	return counts.FilterCount, nil
+}
+
+// Count returns the number of items matching filter, see DirectusClient.Count.
+func (c *ItemsClient[T]) Count(ctx context.Context, filter FilterNode) (int, error) {
+	return c.d.Count(ctx, c.collection, filter)
+}
@@ -0,0 +1,48 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestCount(t *testing.T) {
+	e := emulator.New("token")
+	e.Seed("articles",
+		map[string]any{"title": "a", "status": "published"},
+		map[string]any{"title": "b", "status": "draft"},
+		map[string]any{"title": "c", "status": "published"},
+	)
+	var limits []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	n, err := client.Count(ctx, "articles", Filter{"status": {OP_eq: "published"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	n, err = Items[article](client, "articles").Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, []string{"0", "0"}, limits)
+
+	_, err = client.Count(ctx, "missing", nil)
+	require.ErrorIs(t, err, ErrForbidden)
+
+	// counts decode into DirectusResult too
+	meta := MetaQueryAll
+	resp, err := client.QueryContext(ctx, "GET", "articles", DirectusQuery{Filter: Filter{"status": {OP_eq: "draft"}}, Meta: &meta}, nil)
+	require.NoError(t, err)
+	result := ReadResult[[]article](resp)
+	require.False(t, result.Err(), result.Errors)
+	require.Equal(t, &Meta{TotalCount: 3, FilterCount: 1}, result.Meta)
+}
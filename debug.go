@@ -0,0 +1,162 @@
+package directus_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// WithDebug logs every request sent to Directus and its response, headers and
+// bodies included, as well as the cache keys of cache hits, at debug level.
+// The token is redacted from the Authorization header, cookies and the
+// access_token query parameter, and so are password and token fields of JSON
+// bodies, e.g. of /auth, user and share requests. Bodies are buffered in
+// memory, so it is meant for diagnosing filter encoding and cache key issues,
+// not for production.
+func WithDebug() ClientOption {
+	return func(d *DirectusClient) {
+		d.debug = true
+	}
+}
+
+func (d *DirectusClient) dumpRequest(req *http.Request) error {
+	body, err := peekRequestBody(req)
+	if err != nil {
+		return err
+	}
+	d.log.Debug("directus request",
+		"request_id", RequestIDFromContext(req.Context()),
+		"method", req.Method,
+		"url", redactURL(req.URL),
+		"header", redactHeader(req.Header),
+		"body", redactBody(req, body),
+	)
+	return nil
+}
+
+func (d *DirectusClient) dumpResponse(req *http.Request, resp *http.Response, start time.Time) error {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	d.log.Debug("directus response",
+		"request_id", RequestIDFromContext(req.Context()),
+		"status", resp.StatusCode,
+		"duration", time.Since(start),
+		"header", redactHeader(resp.Header),
+		"body", redactBody(req, body),
+	)
+	return nil
+}
+
+// peekRequestBody returns the body of req, leaving it unread.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// sensitiveFields are the JSON fields carrying credentials, e.g. the
+// password of a user or share and the tokens issued by /auth.
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"tfa_secret":    true,
+	"otp":           true,
+}
+
+func redactBody(req *http.Request, body []byte) string {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		// uploads are binary and large
+		return "<" + strconv.Itoa(len(body)) + " bytes of multipart data>"
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || !redactFields(v) {
+		return string(body)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(b)
+}
+
+// redactFields replaces the values of sensitiveFields anywhere in v and
+// reports whether there were any.
+func redactFields(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, o := range v {
+			if sensitiveFields[k] && o != nil {
+				v[k] = redacted
+				found = true
+			} else if redactFields(o) {
+				found = true
+			}
+		}
+	case []any:
+		for _, o := range v {
+			if redactFields(o) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if h.Get("Authorization") != "" {
+		h.Set("Authorization", "Bearer "+redacted)
+	}
+	// forwarded session cookies and the ones set by /auth
+	for _, k := range []string{"Cookie", "Set-Cookie"} {
+		if h.Get(k) != "" {
+			h.Set(k, redacted)
+		}
+	}
+	return h
+}
+
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if !q.Has("access_token") {
+		return u.String()
+	}
+	q.Set("access_token", redacted)
+	r := *u
+	r.RawQuery = q.Encode()
+	return r.String()
+}
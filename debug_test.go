@@ -0,0 +1,109 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/directustest"
+)
+
+func TestDebug(t *testing.T) {
+	l := new(recordingLogger)
+	srv := directustest.NewServer(t)
+	srv.LoadFixtures("testdata/user.json")
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, directustest.Token, cache, WithLogger(l), WithDebug())
+	require.NoError(t, err)
+
+	query := DirectusQuery{
+		Fields: Fields{"id", "email"},
+		Filter: Filter{"email": {OP_eq: "dev@dev.io"}},
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+
+	require.Len(t, l.lines, 3)
+	require.True(t, strings.HasPrefix(l.lines[0], "debug directus request"), l.lines[0])
+	require.Contains(t, l.lines[0], "Bearer [REDACTED]")
+	require.Contains(t, l.lines[0], mustEncode(t, query))
+	require.True(t, strings.HasPrefix(l.lines[1], "debug directus response"), l.lines[1])
+	require.Contains(t, l.lines[1], "dev@dev.io")
+	require.True(t, strings.HasPrefix(l.lines[2], "debug directus cache hit"), l.lines[2])
+	for _, line := range l.lines {
+		require.NotContains(t, line, directustest.Token)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, err := url.Parse("http://directus/items/user?access_token=secret&limit=1")
+	require.NoError(t, err)
+	require.Equal(t, "http://directus/items/user?access_token=%5BREDACTED%5D&limit=1", redactURL(u))
+}
+
+func TestDebugRedactsCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"access_token":"issued-access","refresh_token":"issued-refresh","expires":900000}}`))
+	}))
+	defer srv.Close()
+	l := new(recordingLogger)
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithLogger(l), WithDebug())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.authenticate(ctx, "/auth/login", map[string]string{"email": "dev@dev.io", "password": "s3cret"})
+	require.NoError(t, err)
+	_, err = client.Shares().Auth(ctx, "s1", "s3cret")
+	require.NoError(t, err)
+	_, err = client.Shares().Create(ctx, Share{Collection: "articles", Item: "1", Password: "s3cret"})
+	require.NoError(t, err)
+	_, err = client.Users().Create(ctx, User{Email: "dev@dev.io", Password: "s3cret"})
+	require.NoError(t, err)
+	_, err = client.Users().UpdateMe(ctx, map[string]any{"password": "s3cret"})
+	require.NoError(t, err)
+
+	require.Len(t, l.lines, 10)
+	for _, line := range l.lines {
+		require.Contains(t, line, "[REDACTED]")
+		for _, secret := range []string{"s3cret", "issued-access", "issued-refresh"} {
+			require.NotContains(t, line, secret)
+		}
+	}
+}
+
+func TestDebugRedactsCookies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "directus_session_token", Value: "issued-session"})
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	l := new(recordingLogger)
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithLogger(l), WithDebug())
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), forwardedAuthKey{}, forwardedAuth{
+		cookie: &http.Cookie{Name: "directus_session_token", Value: "user-session"},
+	})
+	resp, err := client.QueryContext(ctx, "GET", "articles", DirectusQuery{}, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, l.lines, 2)
+	for _, line := range l.lines {
+		require.Contains(t, line, "[REDACTED]")
+		require.NotContains(t, line, "user-session")
+		require.NotContains(t, line, "issued-session")
+	}
+}
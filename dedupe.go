@@ -0,0 +1,108 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// WithDeduplication makes concurrent identical GET requests share a single
+// round trip to Directus, whether or not a cache is configured. Deduplicated
+// responses are buffered in full before they are handed out, so it suits
+// many small queries better than large exports. The shared round trip is
+// made with the request of the first caller, with its request id and
+// hooks; callers that join it and outlive a cancelled first caller make
+// their own request.
+func WithDeduplication() ClientOption {
+	return func(d *DirectusClient) {
+		d.inflight = &inflightGroup{calls: make(map[string]*inflightCall)}
+	}
+}
+
+type inflightCall struct {
+	done   chan struct{}
+	status string
+	code   int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// inflightKey hashes the query with its parameters sorted, so proxied
+// requests that only differ in parameter order are deduplicated too.
+func inflightKey(collection string, rawQuery string) string {
+	if v, err := url.ParseQuery(rawQuery); err == nil {
+		rawQuery = v.Encode()
+	}
+	return collection + ":" + strconv.FormatUint(xxhash.Sum64String(rawQuery), 16)
+}
+
+// do runs fetch once for all callers with the same key that arrive while it
+// is in flight. Every caller gets its own response over the shared body. If
+// the context of the caller running fetch ends it, the others try again.
+func (g *inflightGroup) do(key string, req *http.Request, fetch func() (*http.Response, error)) (*http.Response, error) {
+	for {
+		g.mu.Lock()
+		c, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+		case <-req.Context().Done():
+			return nil, &RequestError{RequestIDFromContext(req.Context()), req.Context().Err()}
+		}
+		if c.err != nil && isContextError(c.err) && req.Context().Err() == nil {
+			continue
+		}
+		return c.response(req)
+	}
+	c := &inflightCall{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	resp, err := fetch()
+	if err == nil {
+		c.status, c.code, c.header = resp.Status, resp.StatusCode, resp.Header
+		c.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	c.err = err
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(c.done)
+	return c.response(req)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (c *inflightCall) response(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &http.Response{
+		Status:        c.status,
+		StatusCode:    c.code,
+		Header:        c.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplication(t *testing.T) {
+	var hits int64
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithDeduplication())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]DirectusResult[[]user], 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", srv.URL+"/items/user?limit=10&fields=id,email", nil)
+			if i%2 == 1 {
+				// same query with the parameters in another order
+				req, _ = http.NewRequest("GET", srv.URL+"/items/user?fields=id,email&limit=10", nil)
+			}
+			resp, err := client.Call(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results[i] = ReadResult[[]user](resp)
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.EqualValues(t, 1, atomic.LoadInt64(&hits))
+	for _, result := range results {
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+
+	// finished requests are not reused
+	_, err = client.Query("GET", "user", DirectusQuery{Fields: Fields{"id"}, Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt64(&hits))
+}
+
+func TestDeduplicationLeaderCancelled(t *testing.T) {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&hits, 1) == 1 {
+			// the first request hangs until its caller gives up
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithDeduplication())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/items/user?limit=10", nil)
+		_, err := client.Call(req)
+		leader <- err
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt64(&hits) == 1 }, time.Second, time.Millisecond)
+
+	follower := make(chan DirectusResult[[]user], 1)
+	go func() {
+		req, _ := http.NewRequest("GET", srv.URL+"/items/user?limit=10", nil)
+		resp, err := client.Call(req)
+		if err != nil {
+			t.Error(err)
+			close(follower)
+			return
+		}
+		follower <- ReadResult[[]user](resp)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	require.ErrorIs(t, <-leader, context.Canceled)
+	result := <-follower
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	require.EqualValues(t, 2, atomic.LoadInt64(&hits))
+}
@@ -0,0 +1,212 @@
+package directustest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Spec is the subset of the OpenAPI document served by /server/specs/oas
+// needed to check that requests built by the client are still understood by
+// the Directus version it talks to.
+type Spec struct {
+	Paths      map[string]*pathItem `json:"paths"`
+	Components struct {
+		Parameters map[string]*parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type pathItem struct {
+	Parameters []*parameter
+	Operations map[string]*operation
+}
+
+func (p *pathItem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Operations = make(map[string]*operation)
+	for k, v := range raw {
+		switch k {
+		case "parameters":
+			if err := json.Unmarshal(v, &p.Parameters); err != nil {
+				return err
+			}
+		case "get", "put", "post", "delete", "options", "head", "patch", "trace", "search":
+			op := new(operation)
+			if err := json.Unmarshal(v, op); err != nil {
+				return err
+			}
+			p.Operations[k] = op
+		}
+	}
+	return nil
+}
+
+type operation struct {
+	Parameters []*parameter `json:"parameters"`
+}
+
+type parameter struct {
+	Ref      string `json:"$ref"`
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Style    string `json:"style"`
+}
+
+// LoadSpec reads an OpenAPI document saved to disk.
+func LoadSpec(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Spec
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// FetchSpec reads the OpenAPI document of a running instance.
+func FetchSpec(baseURL string, token string) (*Spec, error) {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(baseURL, "/")+"/server/specs/oas", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := (&http.Client{Timeout: time.Second * 30}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("fetch oas: " + resp.Status)
+	}
+	var s Spec
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks that the path and method of req are documented and that
+// every query parameter is declared and every required one is present.
+func (s *Spec) Validate(req *http.Request) error {
+	op, params, err := s.lookup(req.Method, req.URL.Path)
+	if err != nil {
+		return err
+	}
+	declared := make(map[string]*parameter)
+	for _, p := range op.Parameters {
+		if p = s.resolve(p); p != nil && p.In == "query" {
+			declared[p.Name] = p
+		}
+	}
+	for _, p := range params {
+		if p = s.resolve(p); p != nil && p.In == "query" {
+			declared[p.Name] = p
+		}
+	}
+
+	var problems []string
+	for name := range req.URL.Query() {
+		base := name
+		// deepObject parameters are sent as name[key]=value
+		if i := strings.IndexByte(name, '['); i > 0 {
+			base = name[:i]
+		}
+		if _, ok := declared[base]; !ok {
+			problems = append(problems, "undeclared query parameter "+name)
+		}
+	}
+	for name, p := range declared {
+		if p.Required && !req.URL.Query().Has(name) {
+			problems = append(problems, "missing required query parameter "+name)
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// lookup finds the operation for path, preferring literal paths such as
+// /items/articles over templated ones such as /items/{collection}.
+func (s *Spec) lookup(method string, path string) (*operation, []*parameter, error) {
+	var (
+		best      *pathItem
+		bestScore = -1
+	)
+	for tmpl, item := range s.Paths {
+		if score, ok := matchPath(tmpl, path); ok && score > bestScore {
+			best, bestScore = item, score
+		}
+	}
+	if best == nil {
+		return nil, nil, fmt.Errorf("%s %s: path not in spec", method, path)
+	}
+	op, ok := best.Operations[strings.ToLower(method)]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s %s: method not in spec", method, path)
+	}
+	return op, best.Parameters, nil
+}
+
+// matchPath reports whether path matches the template and how many segments
+// matched literally.
+func matchPath(tmpl string, path string) (int, bool) {
+	ts := strings.Split(strings.Trim(tmpl, "/"), "/")
+	ps := strings.Split(strings.Trim(path, "/"), "/")
+	if len(ts) != len(ps) {
+		return 0, false
+	}
+	score := 0
+	for i := range ts {
+		switch {
+		case strings.HasPrefix(ts[i], "{") && strings.HasSuffix(ts[i], "}"):
+		case ts[i] == ps[i]:
+			score++
+		default:
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+func (s *Spec) resolve(p *parameter) *parameter {
+	if p == nil || p.Ref == "" {
+		return p
+	}
+	return s.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+}
+
+type contractTransport struct {
+	t    testing.TB
+	spec *Spec
+	next http.RoundTripper
+}
+
+// Transport returns a RoundTripper that fails t for every request violating
+// the spec before passing it on to next (http.DefaultTransport if nil).
+func (s *Spec) Transport(t testing.TB, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &contractTransport{t, s, next}
+}
+
+func (c *contractTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := c.spec.Validate(req); err != nil {
+		c.t.Errorf("directustest: contract violation: %v", err)
+	}
+	return c.next.RoundTrip(req)
+}
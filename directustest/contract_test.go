@@ -0,0 +1,53 @@
+package directustest
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	directus_client "gitlab.enkuchat.com/backend/directus_client"
+)
+
+func TestSpecValidate(t *testing.T) {
+	spec, err := LoadSpec("testdata/oas.json")
+	require.NoError(t, err)
+
+	q := directus_client.DirectusQuery{
+		Fields: directus_client.Fields{"id", "email"},
+		Filter: directus_client.Filter{"email": {directus_client.OP_eq: "dev@dev.io"}},
+		Sort:   directus_client.Fields{"-id"},
+	}
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	req := &http.Request{Method: "GET", URL: &url.URL{Path: "/items/user", RawQuery: v.Encode()}}
+	require.NoError(t, spec.Validate(req))
+
+	req.URL.RawQuery = "fields=id&page=2"
+	require.EqualError(t, spec.Validate(req), "GET /items/user: undeclared query parameter page")
+
+	req.URL.Path = "/items/user/1"
+	req.URL.RawQuery = "fields=id"
+	require.NoError(t, spec.Validate(req))
+
+	req.Method = "DELETE"
+	require.EqualError(t, spec.Validate(req), "DELETE /items/user/1: method not in spec")
+
+	req.URL.Path = "/items/article"
+	require.EqualError(t, spec.Validate(req), "DELETE /items/article: path not in spec")
+}
+
+func TestSpecTransport(t *testing.T) {
+	spec, err := LoadSpec("testdata/oas.json")
+	require.NoError(t, err)
+	srv := NewServer(t, Fixture{Collection: "user", Body: []byte(`{"data": []}`)})
+
+	client, err := directus_client.NewDirectusClient(srv.URL, Token, directus_client.NewNoopQueryCache(),
+		directus_client.WithHTTPClient(&http.Client{Transport: spec.Transport(t, nil)}))
+	require.NoError(t, err)
+	_, err = client.Query("GET", "user", directus_client.DirectusQuery{
+		Fields: directus_client.Fields{"id"},
+		Filter: directus_client.Filter{"id": {directus_client.OP_in: []int{1, 2}}},
+	}, nil)
+	require.NoError(t, err)
+}
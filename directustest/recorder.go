@@ -0,0 +1,246 @@
+package directustest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecordEnv forces a Recorder to re-record its cassette when set to a
+// non-empty value, e.g. DIRECTUS_RECORD=1 go test ./...
+const RecordEnv = "DIRECTUS_RECORD"
+
+const redacted = "REDACTED"
+
+// Interaction is a recorded request/response pair. Hosts are not recorded so
+// a cassette replays against any base URL.
+type Interaction struct {
+	Method       string      `json:"method"`
+	URI          string      `json:"uri"`
+	RequestBody  string      `json:"request_body,omitempty"`
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header,omitempty"`
+	ResponseBody string      `json:"response_body"`
+	replayed     bool
+}
+
+// Recorder is a VCR style http.RoundTripper. If its cassette file exists the
+// recorded responses are replayed and the network is never touched; otherwise
+// (or when RecordEnv is set) requests go through to Directus and are written
+// to the cassette by Stop. Tokens are scrubbed before anything hits the disk:
+// Bearer tokens, the tokens of /auth requests and responses and secrets
+// registered with Scrub, from every interaction whenever they were learned.
+type Recorder struct {
+	path      string
+	next      http.RoundTripper
+	recording bool
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	secrets      []string
+}
+
+// NewRecorder loads the cassette at path. next performs real requests while
+// recording and defaults to http.DefaultTransport.
+func NewRecorder(path string, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{path: path, next: next}
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) || os.Getenv(RecordEnv) != "":
+		r.recording = true
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(b, &r.interactions); err != nil {
+			return nil, fmt.Errorf("directustest: decode cassette %s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Recording reports whether requests go to the network.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Scrub registers additional secrets to redact from recorded URLs and
+// bodies. Bearer tokens and the tokens of /auth are always scrubbed.
+func (r *Recorder) Scrub(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+next:
+	for _, s := range secrets {
+		if s == "" {
+			continue
+		}
+		for _, known := range r.secrets {
+			if known == s {
+				continue next
+			}
+		}
+		r.secrets = append(r.secrets, s)
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "); token != req.Header.Get("Authorization") {
+		r.Scrub(token)
+	}
+	if isAuthPath(req.URL.Path) {
+		r.Scrub(tokenFields(body)...)
+	}
+	if r.recording {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if isAuthPath(req.URL.Path) {
+		r.Scrub(tokenFields(respBody)...)
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	header.Del("Date")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, &Interaction{
+		Method:       req.Method,
+		URI:          r.scrub(req.URL.RequestURI()),
+		RequestBody:  r.scrub(string(body)),
+		Status:       resp.StatusCode,
+		Header:       header,
+		ResponseBody: r.scrub(string(respBody)),
+	})
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request, falling
+// back to the last used one so repeated identical requests keep working.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	uri, reqBody := r.scrub(req.URL.RequestURI()), r.scrub(string(body))
+	var match *Interaction
+	for _, i := range r.interactions {
+		if i.Method != req.Method || i.URI != uri || i.RequestBody != reqBody {
+			continue
+		}
+		match = i
+		if !i.replayed {
+			break
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("directustest: no recorded interaction for %s %s in %s", req.Method, uri, r.path)
+	}
+	match.replayed = true
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Status, http.StatusText(match.Status)),
+		StatusCode:    match.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        match.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(match.ResponseBody)),
+		ContentLength: int64(len(match.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// isAuthPath reports whether path is an endpoint issuing or taking tokens.
+func isAuthPath(path string) bool {
+	return strings.HasPrefix(path, "/auth/") || path == "/shares/auth"
+}
+
+// tokenFields returns the values of the token and password fields anywhere
+// in the JSON body.
+func tokenFields(body []byte) []string {
+	var v any
+	if json.Unmarshal(body, &v) != nil {
+		return nil
+	}
+	var tokens []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, o := range v {
+				switch s, _ := o.(string); k {
+				case "access_token", "refresh_token", "password":
+					tokens = append(tokens, s)
+				default:
+					walk(o)
+				}
+			}
+		case []any:
+			for _, o := range v {
+				walk(o)
+			}
+		}
+	}
+	walk(v)
+	return tokens
+}
+
+// Stop writes the cassette if the recorder was recording. Interactions are
+// scrubbed again, as a secret may have been learned after it was recorded.
+func (r *Recorder) Stop() error {
+	if !r.recording {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range r.interactions {
+		i.URI = r.scrub(i.URI)
+		i.RequestBody = r.scrub(i.RequestBody)
+		i.ResponseBody = r.scrub(i.ResponseBody)
+	}
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, b, 0644)
+}
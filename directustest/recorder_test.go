@@ -0,0 +1,95 @@
+package directustest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	directus_client "gitlab.enkuchat.com/backend/directus_client"
+)
+
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "user.json")
+	query := directus_client.DirectusQuery{
+		Fields: directus_client.Fields{"id"},
+		Filter: directus_client.Filter{"id": {directus_client.OP_eq: 1}},
+	}
+
+	srv := NewServer(t, Fixture{Collection: "user", Body: []byte(`{"data": [{"id": 1}]}`)})
+	rec, err := NewRecorder(cassette, nil)
+	require.NoError(t, err)
+	require.True(t, rec.Recording())
+	client, err := directus_client.NewDirectusClient(srv.URL, Token, directus_client.NewNoopQueryCache(),
+		directus_client.WithHTTPClient(&http.Client{Transport: rec}))
+	require.NoError(t, err)
+	_, err = client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	require.NoError(t, rec.Stop())
+	srv.Close()
+
+	b, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(b), Token))
+
+	rec, err = NewRecorder(cassette, nil)
+	require.NoError(t, err)
+	require.False(t, rec.Recording())
+	client, err = directus_client.NewDirectusClient("http://directus.invalid", Token, directus_client.NewNoopQueryCache(),
+		directus_client.WithHTTPClient(&http.Client{Transport: rec}))
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := directus_client.ReadResult[[]map[string]int](resp)
+		require.False(t, result.Err())
+		require.Equal(t, []map[string]int{{"id": 1}}, result.Data)
+	}
+}
+
+func TestRecorderScrubsTokens(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "auth.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/login":
+			w.Write([]byte(`{"data":{"access_token":"issued-access","refresh_token":"issued-refresh","expires":900000}}`))
+		case "/items/keys":
+			w.Write([]byte(`{"data":[{"key":"late-secret"}]}`))
+		default:
+			w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer srv.Close()
+	rec, err := NewRecorder(cassette, nil)
+	require.NoError(t, err)
+	httpClient := directus_client.WithHTTPClient(&http.Client{Transport: rec})
+
+	client, err := directus_client.NewDirectusClientWithLogin(context.Background(), srv.URL, "dev@dev.io", "s3cret", directus_client.NewNoopQueryCache(), httpClient)
+	require.NoError(t, err)
+	_, err = client.Query("GET", "keys", directus_client.DirectusQuery{}, nil)
+	require.NoError(t, err)
+	// a token used only after it was recorded in a response
+	client, err = directus_client.NewDirectusClient(srv.URL, "late-secret", directus_client.NewNoopQueryCache(), httpClient)
+	require.NoError(t, err)
+	_, err = client.Query("GET", "user", directus_client.DirectusQuery{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, rec.Stop())
+
+	b, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	for _, secret := range []string{"s3cret", "issued-access", "issued-refresh", "late-secret"} {
+		require.NotContains(t, string(b), secret)
+	}
+
+	// the login replays with the password scrubbed the same way
+	rec, err = NewRecorder(cassette, nil)
+	require.NoError(t, err)
+	_, err = directus_client.NewDirectusClientWithLogin(context.Background(), "http://directus.invalid", "dev@dev.io", "s3cret", directus_client.NewNoopQueryCache(),
+		directus_client.WithHTTPClient(&http.Client{Transport: rec}))
+	require.NoError(t, err)
+}
@@ -0,0 +1,227 @@
+// Package directustest provides an httptest based Directus stand-in that
+// serves recorded JSON fixtures, so client code can be tested without
+// DIRECTUS_URL/DIRECTUS_TOKEN or network access.
+package directustest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Token is the static token the server accepts.
+const Token = "directustest-token"
+
+// Fixture is a recorded response for requests to Collection. Query holds the
+// parameters a request must carry to match; filter is compared as JSON so key
+// order and whitespace don't matter. An empty Query matches any request.
+type Fixture struct {
+	Method     string            `json:"method,omitempty"`
+	Collection string            `json:"collection"`
+	Query      map[string]string `json:"query,omitempty"`
+	Status     int               `json:"status,omitempty"`
+	Body       json.RawMessage   `json:"body"`
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method     string
+	Collection string
+	Query      url.Values
+	Body       []byte
+}
+
+// Fields returns the requested fields.
+func (r Request) Fields() []string {
+	fields := r.Query.Get("fields")
+	if fields == "" {
+		return nil
+	}
+	return strings.Split(fields, ",")
+}
+
+// Filter returns the decoded filter parameter, or nil if not set.
+func (r Request) Filter() any {
+	filter := r.Query.Get("filter")
+	if filter == "" {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(filter), &v); err != nil {
+		return filter
+	}
+	return v
+}
+
+type Server struct {
+	*httptest.Server
+	t testing.TB
+
+	mu       sync.Mutex
+	fixtures []Fixture
+	requests []Request
+}
+
+// NewServer starts a server that is closed when the test finishes.
+func NewServer(t testing.TB, fixtures ...Fixture) *Server {
+	s := &Server{t: t, fixtures: fixtures}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// LoadFixtures adds the fixtures stored as a JSON array in path.
+func (s *Server) LoadFixtures(path string) {
+	s.t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		s.t.Fatalf("directustest: read fixtures: %v", err)
+	}
+	var fixtures []Fixture
+	if err := json.Unmarshal(b, &fixtures); err != nil {
+		s.t.Fatalf("directustest: decode fixtures %s: %v", path, err)
+	}
+	s.AddFixture(fixtures...)
+}
+
+// AddFixture adds fixtures. Later fixtures take precedence over earlier ones.
+func (s *Server) AddFixture(fixtures ...Fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures = append(s.fixtures, fixtures...)
+}
+
+// Requests returns the requests received for collection, or all requests if
+// collection is empty.
+func (s *Server) Requests(collection string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []Request
+	for _, r := range s.requests {
+		if collection == "" || r.Collection == collection {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// AssertFields fails the test unless the last request to collection asked
+// for exactly fields.
+func (s *Server) AssertFields(collection string, fields ...string) {
+	s.t.Helper()
+	r, ok := s.lastRequest(collection)
+	if !ok {
+		return
+	}
+	if !reflect.DeepEqual(r.Fields(), fields) {
+		s.t.Errorf("directustest: %s fields = %v, want %v", collection, r.Fields(), fields)
+	}
+}
+
+// AssertFilter fails the test unless the last request to collection carried
+// a filter equal to filter once both are encoded as JSON.
+func (s *Server) AssertFilter(collection string, filter any) {
+	s.t.Helper()
+	r, ok := s.lastRequest(collection)
+	if !ok {
+		return
+	}
+	b, err := json.Marshal(filter)
+	if err != nil {
+		s.t.Fatalf("directustest: encode filter: %v", err)
+	}
+	if !jsonEqual(r.Query.Get("filter"), string(b)) {
+		s.t.Errorf("directustest: %s filter = %s, want %s", collection, r.Query.Get("filter"), b)
+	}
+}
+
+func (s *Server) lastRequest(collection string) (Request, bool) {
+	s.t.Helper()
+	requests := s.Requests(collection)
+	if len(requests) == 0 {
+		s.t.Errorf("directustest: no request to %s", collection)
+		return Request{}, false
+	}
+	return requests[len(requests)-1], true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		writeError(w, http.StatusUnauthorized, "Invalid user credentials.")
+		return
+	}
+	split := strings.SplitN(r.URL.Path, "items/", 2)
+	if len(split) != 2 {
+		writeError(w, http.StatusNotFound, "Route "+r.URL.Path+" doesn't exist.")
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	req := Request{Method: r.Method, Collection: split[1], Query: r.URL.Query(), Body: body}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	var match *Fixture
+	for i := len(s.fixtures) - 1; i >= 0; i-- {
+		if s.fixtures[i].matches(req) {
+			match = &s.fixtures[i]
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if match == nil {
+		s.t.Errorf("directustest: no fixture for %s %s?%s", r.Method, req.Collection, r.URL.RawQuery)
+		writeError(w, http.StatusNotFound, "no fixture")
+		return
+	}
+	status := match.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(match.Body)
+}
+
+func (f *Fixture) matches(r Request) bool {
+	if f.Collection != r.Collection {
+		return false
+	}
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	for k, v := range f.Query {
+		got := r.Query.Get(k)
+		if k == "filter" {
+			if !jsonEqual(got, v) {
+				return false
+			}
+		} else if got != v {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonEqual(a, b string) bool {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]any{{"message": message}},
+	})
+}
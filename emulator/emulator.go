@@ -0,0 +1,369 @@
+// Package emulator implements a small in-memory stand-in for the Directus
+// /items API, so apps built on directus_client can run offline in development
+// and demos. It supports CRUD plus the fields, filter, sort, limit, offset,
+// page and meta query parameters; relations and permissions are not emulated.
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultLimit = 100
+
+type collection struct {
+	items  []map[string]any
+	nextID int
+}
+
+type Emulator struct {
+	mu          sync.RWMutex
+	token       string
+	collections map[string]*collection
+}
+
+// New creates an empty emulator. If token is not empty requests must carry it
+// as a bearer token.
+func New(token string) *Emulator {
+	return &Emulator{token: token, collections: make(map[string]*collection)}
+}
+
+// Seed appends items to collection, assigning numeric ids to items without one.
+func (e *Emulator) Seed(name string, items ...map[string]any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c := e.collection(name)
+	for _, item := range items {
+		c.insert(item)
+	}
+}
+
+// LoadSeed reads a JSON object mapping collection names to arrays of items.
+// If path is a directory every *.json file in it is loaded, named after the
+// collection it holds an array of items for.
+func (e *Emulator) LoadSeed(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var seed map[string][]map[string]any
+		if err := json.Unmarshal(b, &seed); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for name, items := range seed {
+			e.Seed(name, items...)
+		}
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		var items []map[string]any
+		if err := json.Unmarshal(b, &items); err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+		e.Seed(strings.TrimSuffix(filepath.Base(f), ".json"), items...)
+	}
+	return nil
+}
+
+func (e *Emulator) collection(name string) *collection {
+	c, ok := e.collections[name]
+	if !ok {
+		c = &collection{nextID: 1}
+		e.collections[name] = c
+	}
+	return c
+}
+
+func (c *collection) insert(item map[string]any) map[string]any {
+	if id, ok := item["id"]; ok && id != nil {
+		if n, ok := number(id); ok && int(n) >= c.nextID {
+			c.nextID = int(n) + 1
+		}
+	} else {
+		item["id"] = float64(c.nextID)
+		c.nextID++
+	}
+	c.items = append(c.items, item)
+	return item
+}
+
+func (c *collection) find(id string) int {
+	for i, item := range c.items {
+		if str(item["id"]) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e *Emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if e.token != "" && r.Header.Get("Authorization") != "Bearer "+e.token && r.URL.Query().Get("access_token") != e.token {
+		writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid user credentials.")
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "items" {
+		writeError(w, http.StatusNotFound, "ROUTE_NOT_FOUND", "Route "+r.URL.Path+" doesn't exist.")
+		return
+	}
+	name := parts[1]
+	id := ""
+	if len(parts) == 3 {
+		id = parts[2]
+	}
+
+	switch {
+	case r.Method == "GET" && id == "":
+		e.list(w, r, name)
+	case r.Method == "GET":
+		e.get(w, r, name, id)
+	case r.Method == "POST" && id == "":
+		e.create(w, r, name)
+	case r.Method == "PATCH" && id != "":
+		e.update(w, r, name, id)
+	case r.Method == "DELETE":
+		e.delete(w, r, name, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "ROUTE_NOT_FOUND", "Method not allowed.")
+	}
+}
+
+func (e *Emulator) list(w http.ResponseWriter, r *http.Request, name string) {
+	q := r.URL.Query()
+	var filter map[string]any
+	if f := q.Get("filter"); f != "" {
+		if err := json.Unmarshal([]byte(f), &filter); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_QUERY", "Invalid query. filter: "+err.Error())
+			return
+		}
+	}
+	limit, err := intParam(q, "limit", defaultLimit, -1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+		return
+	}
+	offset, err := intParam(q, "offset", 0, 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+		return
+	}
+	page, err := intParam(q, "page", 0, 1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+		return
+	}
+	if page > 0 && limit > 0 {
+		offset = (page - 1) * limit
+	}
+
+	e.mu.RLock()
+	c, ok := e.collections[name]
+	if !ok {
+		e.mu.RUnlock()
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "You don't have permission to access this.")
+		return
+	}
+	total := len(c.items)
+	var items []map[string]any
+	for _, item := range c.items {
+		m, err := match(item, filter)
+		if err != nil {
+			e.mu.RUnlock()
+			writeError(w, http.StatusBadRequest, "INVALID_QUERY", "Invalid query. "+err.Error())
+			return
+		}
+		if m {
+			items = append(items, project(item, "*"))
+		}
+	}
+	e.mu.RUnlock()
+
+	if s := q.Get("sort"); s != "" {
+		sortItems(items, strings.Split(s, ","))
+	}
+	filtered := len(items)
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	data := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		data = append(data, project(item, q.Get("fields")))
+	}
+	result := map[string]any{"data": data}
+	switch q.Get("meta") {
+	case "*":
+		result["meta"] = map[string]int{"total_count": total, "filter_count": filtered}
+	case "total_count":
+		result["meta"] = map[string]int{"total_count": total}
+	case "filter_count":
+		result["meta"] = map[string]int{"filter_count": filtered}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (e *Emulator) get(w http.ResponseWriter, r *http.Request, name string, id string) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	c, ok := e.collections[name]
+	if !ok || c.find(id) < 0 {
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "You don't have permission to access this.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": project(c.items[c.find(id)], r.URL.Query().Get("fields"))})
+}
+
+func (e *Emulator) create(w http.ResponseWriter, r *http.Request, name string) {
+	var body any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PAYLOAD", err.Error())
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c := e.collection(name)
+	switch body := body.(type) {
+	case map[string]any:
+		writeJSON(w, http.StatusOK, map[string]any{"data": c.insert(body)})
+	case []any:
+		created := make([]map[string]any, 0, len(body))
+		for _, b := range body {
+			item, ok := b.(map[string]any)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "INVALID_PAYLOAD", "items must be objects")
+				return
+			}
+			created = append(created, c.insert(item))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": created})
+	default:
+		writeError(w, http.StatusBadRequest, "INVALID_PAYLOAD", "payload must be an object or an array")
+	}
+}
+
+func (e *Emulator) update(w http.ResponseWriter, r *http.Request, name string, id string) {
+	var patch map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_PAYLOAD", err.Error())
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c, ok := e.collections[name]
+	if !ok || c.find(id) < 0 {
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "You don't have permission to access this.")
+		return
+	}
+	item := c.items[c.find(id)]
+	for k, v := range patch {
+		if k != "id" {
+			item[k] = v
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": item})
+}
+
+func (e *Emulator) delete(w http.ResponseWriter, r *http.Request, name string, id string) {
+	ids := []string{id}
+	if id == "" {
+		var keys []any
+		if err := json.NewDecoder(r.Body).Decode(&keys); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_PAYLOAD", err.Error())
+			return
+		}
+		ids = ids[:0]
+		for _, k := range keys {
+			ids = append(ids, str(k))
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c, ok := e.collections[name]
+	if !ok {
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "You don't have permission to access this.")
+		return
+	}
+	for _, id := range ids {
+		if i := c.find(id); i >= 0 {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// project copies the requested top level fields; relational paths are
+// reduced to their first segment.
+func project(item map[string]any, fields string) map[string]any {
+	out := make(map[string]any)
+	for _, f := range strings.Split(fields, ",") {
+		f = strings.SplitN(f, ".", 2)[0]
+		if f == "*" || f == "" {
+			for k, v := range item {
+				out[k] = v
+			}
+			return out
+		}
+		if v, ok := item[f]; ok {
+			out[f] = v
+		}
+	}
+	return out
+}
+
+// intParam returns the integer parameter name, or def if it is not set. Set
+// values below lower are rejected like Directus does.
+func intParam(q map[string][]string, name string, def int, lower int) (int, error) {
+	v := ""
+	if l := q[name]; len(l) > 0 {
+		v = l[0]
+	}
+	if v == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid query. %s must be a number", name)
+	}
+	if i < lower {
+		return 0, fmt.Errorf("Invalid query. %s must be greater than or equal to %d", name, lower)
+	}
+	return i, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]any{{
+			"message":    message,
+			"extensions": map[string]string{"code": code},
+		}},
+	})
+}
@@ -0,0 +1,116 @@
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	directus_client "gitlab.enkuchat.com/backend/directus_client"
+)
+
+type article struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status,omitempty"`
+}
+
+func TestEmulator(t *testing.T) {
+	e := New("dev")
+	require.NoError(t, e.LoadSeed("testdata/seed.json"))
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+	client, err := directus_client.NewDirectusClient(srv.URL, "dev", directus_client.NewNoopQueryCache())
+	require.NoError(t, err)
+
+	resp, err := client.Query("GET", "articles", directus_client.DirectusQuery{
+		Fields: directus_client.Fields{"id", "title"},
+		Filter: directus_client.Filter{"status": {directus_client.OP_eq: "published"}},
+		Sort:   directus_client.Fields{"-views"},
+	}, nil)
+	require.NoError(t, err)
+	result := directus_client.ReadResult[[]article](resp)
+	require.False(t, result.Err())
+	require.Equal(t, []article{{ID: 3, Title: "World"}, {ID: 1, Title: "Hello"}}, result.Data)
+
+	resp, err = client.Query("POST", "articles", directus_client.DirectusQuery{Filter: directus_client.Filter{}},
+		bytes.NewReader([]byte(`{"title": "New", "status": "draft"}`)))
+	require.NoError(t, err)
+	created := directus_client.ReadResult[article](resp)
+	require.False(t, created.Err())
+	require.Equal(t, article{ID: 4, Title: "New", Status: "draft"}, created.Data)
+
+	resp, err = client.Query("GET", "articles", directus_client.DirectusQuery{
+		Filter: directus_client.Filter{"views": {directus_client.OP_between: []int{5, 30}}},
+		Limit:  1,
+	}, nil)
+	require.NoError(t, err)
+	result = directus_client.ReadResult[[]article](resp)
+	require.False(t, result.Err())
+	require.Equal(t, []article{{ID: 1, Title: "Hello", Status: "published"}}, result.Data)
+
+	req, _ := http.NewRequest("GET", srv.URL+"/items/articles", nil)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestListBounds(t *testing.T) {
+	e := New("dev")
+	require.NoError(t, e.LoadSeed("testdata/seed.json"))
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	for query, status := range map[string]int{
+		"offset=-1": http.StatusBadRequest,
+		"page=0":    http.StatusBadRequest,
+		"page=-1":   http.StatusBadRequest,
+		"limit=-2":  http.StatusBadRequest,
+		"limit=-1":  http.StatusOK,
+		"offset=9":  http.StatusOK,
+		"page=1":    http.StatusOK,
+	} {
+		req, _ := http.NewRequest("GET", srv.URL+"/items/articles?"+query, nil)
+		req.Header.Set("Authorization", "Bearer dev")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		var body struct {
+			Errors []directus_client.DirectusError `json:"errors"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		resp.Body.Close()
+		require.Equal(t, status, resp.StatusCode, query)
+		if status != http.StatusOK {
+			require.Equal(t, "INVALID_QUERY", body.Errors[0].Extensions.Code, query)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	item := map[string]any{"name": "directus", "count": float64(3), "tags": []any{}}
+	for _, c := range []struct {
+		filter map[string]any
+		want   bool
+	}{
+		{map[string]any{"name": map[string]any{"_starts_with": "dir"}}, true},
+		{map[string]any{"name": map[string]any{"_istarts_with": "DIR"}}, true},
+		{map[string]any{"name": map[string]any{"_nicontains": "RECT"}}, false},
+		{map[string]any{"name": map[string]any{"_iends_with": "US"}}, true},
+		{map[string]any{"count": map[string]any{"_in": "1,2,3"}}, true},
+		{map[string]any{"count": map[string]any{"_gt": "3"}}, false},
+		{map[string]any{"tags": map[string]any{"_empty": true}}, true},
+		{map[string]any{"missing": map[string]any{"_null": true}}, true},
+		{map[string]any{"_or": []any{
+			map[string]any{"name": map[string]any{"_eq": "strapi"}},
+			map[string]any{"count": map[string]any{"_lte": 3}},
+		}}, true},
+	} {
+		got, err := match(item, c.filter)
+		require.NoError(t, err)
+		require.Equal(t, c.want, got, c.filter)
+	}
+	_, err := match(item, map[string]any{"name": map[string]any{"_regex": "."}})
+	require.Error(t, err)
+}
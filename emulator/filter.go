@@ -0,0 +1,203 @@
+package emulator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// match reports whether item satisfies a decoded Directus filter. Unknown
+// operators are reported as errors rather than silently matching.
+func match(item map[string]any, filter map[string]any) (bool, error) {
+	for key, cond := range filter {
+		switch key {
+		case "_and", "_or":
+			groups, ok := cond.([]any)
+			if !ok {
+				return false, fmt.Errorf("%s expects an array", key)
+			}
+			matched := false
+			for _, g := range groups {
+				sub, ok := g.(map[string]any)
+				if !ok {
+					return false, fmt.Errorf("%s expects an array of filters", key)
+				}
+				m, err := match(item, sub)
+				if err != nil {
+					return false, err
+				}
+				if key == "_and" && !m {
+					return false, nil
+				}
+				matched = matched || m
+			}
+			if key == "_or" && !matched && len(groups) > 0 {
+				return false, nil
+			}
+			continue
+		}
+		ops, ok := cond.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("invalid filter for field %q", key)
+		}
+		for op, arg := range ops {
+			m, err := apply(op, item[key], arg)
+			if err != nil {
+				return false, fmt.Errorf("field %q: %w", key, err)
+			}
+			if !m {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func apply(op string, v any, arg any) (bool, error) {
+	switch op {
+	case "_eq":
+		return compare(v, arg) == 0, nil
+	case "_neq":
+		return compare(v, arg) != 0, nil
+	case "_lt":
+		return v != nil && compare(v, arg) < 0, nil
+	case "_lte":
+		return v != nil && compare(v, arg) <= 0, nil
+	case "_gt":
+		return v != nil && compare(v, arg) > 0, nil
+	case "_gte":
+		return v != nil && compare(v, arg) >= 0, nil
+	case "_in", "_nin":
+		in := false
+		for _, a := range list(arg) {
+			if compare(v, a) == 0 {
+				in = true
+				break
+			}
+		}
+		return in == (op == "_in"), nil
+	case "_null":
+		return (v == nil) == truthy(arg), nil
+	case "_nnull":
+		return (v != nil) == truthy(arg), nil
+	case "_empty", "_nempty":
+		empty := v == nil || v == "" || (reflect.ValueOf(v).Kind() == reflect.Slice && reflect.ValueOf(v).Len() == 0)
+		if op == "_nempty" {
+			empty = !empty
+		}
+		return empty == truthy(arg), nil
+	case "_contains", "_ncontains":
+		return (v != nil && strings.Contains(str(v), str(arg))) == (op == "_contains"), nil
+	case "_starts_with", "_nstarts_with":
+		return (v != nil && strings.HasPrefix(str(v), str(arg))) == (op == "_starts_with"), nil
+	case "_ends_with", "_nends_with":
+		return (v != nil && strings.HasSuffix(str(v), str(arg))) == (op == "_ends_with"), nil
+	case "_icontains", "_nicontains":
+		return (v != nil && strings.Contains(strings.ToLower(str(v)), strings.ToLower(str(arg)))) == (op == "_icontains"), nil
+	case "_istarts_with", "_nistarts_with":
+		return (v != nil && strings.HasPrefix(strings.ToLower(str(v)), strings.ToLower(str(arg)))) == (op == "_istarts_with"), nil
+	case "_iends_with", "_niends_with":
+		return (v != nil && strings.HasSuffix(strings.ToLower(str(v)), strings.ToLower(str(arg)))) == (op == "_iends_with"), nil
+	case "_between", "_nbetween":
+		bounds := list(arg)
+		if len(bounds) != 2 {
+			return false, fmt.Errorf("%s expects two values", op)
+		}
+		between := v != nil && compare(v, bounds[0]) >= 0 && compare(v, bounds[1]) <= 0
+		return between == (op == "_between"), nil
+	}
+	return false, fmt.Errorf("unsupported operator %q", op)
+}
+
+// compare orders two JSON values, numerically when both look like numbers
+// since query string filters carry numbers as strings.
+func compare(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	fa, aok := number(a)
+	fb, bok := number(b)
+	if aok && bok {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(str(a), str(b))
+}
+
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func str(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	case float64:
+		return v != 0
+	}
+	return v != nil
+}
+
+// list accepts both JSON arrays and the comma separated form used in query
+// strings.
+func list(v any) []any {
+	switch v := v.(type) {
+	case []any:
+		return v
+	case string:
+		var l []any
+		for _, s := range strings.Split(v, ",") {
+			l = append(l, s)
+		}
+		return l
+	}
+	return []any{v}
+}
+
+// sortItems sorts in place by Directus sort syntax, e.g. ["-date", "id"].
+func sortItems(items []map[string]any, fields []string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, f := range fields {
+			desc := strings.HasPrefix(f, "-")
+			f = strings.TrimPrefix(f, "-")
+			c := compare(items[i][f], items[j][f])
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != desc
+		}
+		return false
+	})
+}
@@ -0,0 +1,262 @@
+package directus_client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Decoder decodes a response body of a registered content type into v.
+type Decoder func(r io.Reader, v any) error
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]Decoder{
+		"application/json": decodeJSON,
+	}
+)
+
+// RegisterDecoder makes ReadResult decode responses of contentType with dec,
+// e.g. "application/msgpack" served by a Directus extension. Use it together
+// with WithAccept.
+func RegisterDecoder(contentType string, dec Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[contentType] = dec
+}
+
+// decoderFor falls back to JSON for missing or unknown content types.
+func decoderFor(contentType string) Decoder {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	if dec, ok := decoders[mediaType]; ok && err == nil {
+		return dec
+	}
+	return decoders["application/json"]
+}
+
+// WithAccept asks Directus for the given content types in order of
+// preference, with JSON as the fallback for endpoints that don't support
+// them. Responses are cached in whatever type was served.
+func WithAccept(contentTypes ...string) ClientOption {
+	return func(d *DirectusClient) {
+		accept := make([]string, 0, len(contentTypes)+1)
+		accept = append(accept, contentTypes...)
+		accept = append(accept, "application/json;q=0.9")
+		d.accept = strings.Join(accept, ", ")
+	}
+}
+
+// WithCompressedCache keeps responses compressed in the cache as served by
+// Directus. Proxy forwards them as is to clients accepting their encoding and
+// ReadResult decompresses them transparently.
+func WithCompressedCache() ClientOption {
+	return func(d *DirectusClient) {
+		d.compressedCache = true
+	}
+}
+
+// acceptEncoding is sent to Directus, which compresses large item lists
+// considerably.
+const acceptEncoding = "br, gzip"
+
+// responseBody returns the body of r with its content encoding removed.
+func responseBody(r *http.Response) (io.Reader, error) {
+	return decompress(r.Header.Get("Content-Encoding"), r.Body)
+}
+
+func decompress(enc string, r io.Reader) (io.Reader, error) {
+	switch strings.ToLower(enc) {
+	case "gzip":
+		return gzip.NewReader(r)
+	case "br":
+		return brotli.NewReader(r), nil
+	}
+	return r, nil
+}
+
+// decompressBody makes resp carry its decoded body, so the cache stores plain
+// bodies unless WithCompressedCache is used.
+func decompressBody(resp *http.Response) error {
+	enc := resp.Header.Get("Content-Encoding")
+	if enc == "" {
+		return nil
+	}
+	body, err := decompress(enc, resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{body, resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header value allows enc.
+func acceptsEncoding(header string, enc string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(name), enc) {
+			return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// Cache entries start with cacheEnvelopeMagic, the length of a JSON encoded
+// cacheEnvelope and the envelope itself, followed by the body as served.
+// Entries written before envelopes existed are plain JSON bodies.
+const cacheEnvelopeMagic = "\x00dce1"
+
+type cacheEnvelope struct {
+	ContentType     string `json:"content_type,omitempty"`
+	ContentEncoding string `json:"content_encoding,omitempty"`
+}
+
+func writeCacheEnvelope(buf *bytes.Buffer, h http.Header) error {
+	meta, err := currentCodec().Marshal(cacheEnvelope{
+		ContentType:     h.Get("Content-Type"),
+		ContentEncoding: h.Get("Content-Encoding"),
+	})
+	if err != nil {
+		return err
+	}
+	var n [binary.MaxVarintLen64]byte
+	buf.WriteString(cacheEnvelopeMagic)
+	buf.Write(n[:binary.PutUvarint(n[:], uint64(len(meta)))])
+	buf.Write(meta)
+	return nil
+}
+
+// readCacheEnvelope splits a cache entry into its envelope and body. It only
+// copies the envelope, so string entries stay zero-copy.
+func readCacheEnvelope[T string | []byte](data T) (cacheEnvelope, T) {
+	legacy := cacheEnvelope{ContentType: "application/json; charset=utf-8"}
+	if len(data) < len(cacheEnvelopeMagic) || string(data[:len(cacheEnvelopeMagic)]) != cacheEnvelopeMagic {
+		return legacy, data
+	}
+	rest := data[len(cacheEnvelopeMagic):]
+	head := rest
+	if len(head) > binary.MaxVarintLen64 {
+		head = head[:binary.MaxVarintLen64]
+	}
+	size, n := binary.Uvarint([]byte(head))
+	if n <= 0 || uint64(len(rest)-n) < size {
+		return legacy, data
+	}
+	env, ok := decodeCacheEnvelope(string(rest[n : n+int(size)]))
+	if !ok {
+		return legacy, data
+	}
+	return env, rest[n+int(size):]
+}
+
+// Only a handful of distinct envelopes exist (one per content type and
+// encoding), so decoded ones are memoized to keep cache hits allocation free.
+var (
+	envelopesMu sync.RWMutex
+	envelopes   = make(map[string]cacheEnvelope)
+)
+
+func decodeCacheEnvelope(meta string) (cacheEnvelope, bool) {
+	envelopesMu.RLock()
+	env, ok := envelopes[meta]
+	envelopesMu.RUnlock()
+	if ok {
+		return env, true
+	}
+	if err := currentCodec().Unmarshal([]byte(meta), &env); err != nil {
+		return env, false
+	}
+	envelopesMu.Lock()
+	if len(envelopes) < 64 {
+		envelopes[meta] = env
+	}
+	envelopesMu.Unlock()
+	return env, true
+}
+
+// pooledStringReader reads a string through a pooled strings.Reader. It is a
+// handle rather than the pooled value itself, so closing it again, as
+// bodies often are, can't return a reader to the pool twice or one that has
+// been handed out since.
+type pooledStringReader struct {
+	r *strings.Reader
+}
+
+var stringReaderPool = sync.Pool{
+	New: func() any { return new(strings.Reader) },
+}
+
+func newPooledStringReader(s string) *pooledStringReader {
+	r := stringReaderPool.Get().(*strings.Reader)
+	r.Reset(s)
+	return &pooledStringReader{r: r}
+}
+
+func (r *pooledStringReader) Read(p []byte) (int, error) {
+	if r.r == nil {
+		return 0, http.ErrBodyReadAfterClose
+	}
+	return r.r.Read(p)
+}
+
+func (r *pooledStringReader) WriteTo(w io.Writer) (int64, error) {
+	if r.r == nil {
+		return 0, http.ErrBodyReadAfterClose
+	}
+	return r.r.WriteTo(w)
+}
+
+// Close returns the reader to the pool; later calls do nothing.
+func (r *pooledStringReader) Close() error {
+	if r.r == nil {
+		return nil
+	}
+	r.r.Reset("")
+	stringReaderPool.Put(r.r)
+	r.r = nil
+	return nil
+}
+
+func cachedResponse(data []byte) *http.Response {
+	env, body := readCacheEnvelope(data)
+	return newCachedResponse(env, io.NopCloser(bytes.NewReader(body)), len(body))
+}
+
+// cachedStringResponse serves a string cache entry through a pooled reader,
+// so a cache hit costs neither a []byte copy nor a bytes.Reader allocation.
+func cachedStringResponse(data string) *http.Response {
+	env, body := readCacheEnvelope(data)
+	return newCachedResponse(env, newPooledStringReader(body), len(body))
+}
+
+func newCachedResponse(env cacheEnvelope, body io.ReadCloser, size int) *http.Response {
+	header := http.Header{}
+	if env.ContentType != "" {
+		header.Set("Content-Type", env.ContentType)
+	}
+	if env.ContentEncoding != "" {
+		header.Set("Content-Encoding", env.ContentEncoding)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          body,
+		ContentLength: int64(size),
+	}
+}
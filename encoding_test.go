@@ -0,0 +1,166 @@
+package directus_client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/require"
+)
+
+// lineDecoder decodes "text/x-lines" bodies, one email per line, standing in
+// for a binary encoding such as msgpack.
+func lineDecoder(r io.Reader, v any) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var users []user
+	for i, email := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		users = append(users, user{i + 1, email})
+	}
+	data, _ := json.Marshal(map[string]any{"data": users})
+	return json.Unmarshal(data, v)
+}
+
+func TestAcceptAndCompressedCache(t *testing.T) {
+	RegisterDecoder("text/x-lines", lineDecoder)
+	var upstream int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		var out io.Writer = w
+		if acceptsEncoding(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+			out = gz
+		}
+		if strings.HasPrefix(r.Header.Get("Accept"), "text/x-lines") {
+			w.Header().Set("Content-Type", "text/x-lines")
+			io.WriteString(out, "dev@dev.io\nops@dev.io\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(out, `{"data": [{"id": 1, "email": "dev@dev.io"}]}`)
+	}))
+	defer srv.Close()
+
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache, WithAccept("text/x-lines"), WithCompressedCache())
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_gt: 0}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+		require.Equal(t, []user{{1, "dev@dev.io"}, {2, "ops@dev.io"}}, result.Data)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&upstream))
+
+	// the proxy decompresses for clients that don't accept gzip
+	w := httptest.NewRecorder()
+	client.Proxy(0).ServeHTTP(w, httptest.NewRequest("GET", "/items/user?"+mustEncode(t, query), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Content-Encoding"))
+	require.Equal(t, "text/x-lines", w.Header().Get("Content-Type"))
+	require.Equal(t, "dev@dev.io\nops@dev.io\n", w.Body.String())
+	require.EqualValues(t, 1, atomic.LoadInt32(&upstream))
+}
+
+func TestDecompressBeforeCache(t *testing.T) {
+	var upstream int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		require.True(t, acceptsEncoding(r.Header.Get("Accept-Encoding"), "br"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "br")
+		br := brotli.NewWriter(w)
+		defer br.Close()
+		io.WriteString(br, `{"data": [{"id": 1, "email": "dev@dev.io"}]}`)
+	}))
+	defer srv.Close()
+
+	store := &memoryCacheService{m: make(map[string][]byte)}
+	cache, err := NewRefreshableQueryCache(store, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		require.Empty(t, resp.Header.Get("Content-Encoding"))
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&upstream))
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	require.Len(t, store.m, 1)
+	for _, entry := range store.m {
+		env, body := readCacheEnvelope(entry)
+		require.Empty(t, env.ContentEncoding)
+		require.JSONEq(t, `{"data": [{"id": 1, "email": "dev@dev.io"}]}`, string(body))
+	}
+}
+
+func TestLegacyCacheEntry(t *testing.T) {
+	resp := cachedResponse([]byte(`{"data": [{"id": 1, "email": "dev@dev.io"}]}`))
+	result := ReadResult[[]user](resp)
+	require.False(t, result.Err())
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+}
+
+func mustEncode(t *testing.T, q DirectusQuery) string {
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	return v.Encode()
+}
+
+func TestCachedStringResponse(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeCacheEnvelope(buf, http.Header{"Content-Type": {"application/json"}}))
+	buf.WriteString(`{"data": [{"id": 1, "email": "dev@dev.io"}]}`)
+
+	resp := cachedStringResponse(buf.String())
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	result := ReadResult[[]user](resp)
+	require.NoError(t, resp.Body.Close())
+	require.False(t, result.Err())
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+}
+
+func TestPooledStringReaderCloseTwice(t *testing.T) {
+	first := newPooledStringReader("first")
+	require.NoError(t, first.Close())
+	require.NoError(t, first.Close())
+	_, err := first.Read(make([]byte, 1))
+	require.ErrorIs(t, err, http.ErrBodyReadAfterClose)
+
+	// two later bodies never share a reader
+	a := newPooledStringReader("a")
+	b := newPooledStringReader("b")
+	got, err := io.ReadAll(a)
+	require.NoError(t, err)
+	require.Equal(t, "a", string(got))
+	got, err = io.ReadAll(b)
+	require.NoError(t, err)
+	require.Equal(t, "b", string(got))
+	require.NoError(t, a.Close())
+	require.NoError(t, b.Close())
+}
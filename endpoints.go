@@ -0,0 +1,261 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// failed endpoints are skipped for this long unless a health check brings
+// them back earlier
+const endpointCooldown = 10 * time.Second
+
+type endpoint struct {
+	url *url.URL
+	// set by the health check
+	down int32
+	// unix nanos until which a failed endpoint is skipped
+	downUntil int64
+}
+
+func (e *endpoint) healthy(now int64) bool {
+	return atomic.LoadInt32(&e.down) == 0 && now >= atomic.LoadInt64(&e.downUntil)
+}
+
+func (e *endpoint) fail() {
+	atomic.StoreInt64(&e.downUntil, time.Now().Add(endpointCooldown).UnixNano())
+}
+
+// endpointPool routes writes to the primary, or the first healthy standby
+// when it is down, and spreads reads over the healthy replicas, using the
+// primary and standbys when none is left.
+type endpointPool struct {
+	primary    *endpoint
+	standbys   []*endpoint
+	replicas   []*endpoint
+	roundRobin bool
+	next       uint32
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// WithFailover adds standby Directus instances sharing the database of the
+// primary base URL. Requests go to the first healthy one in order; reads fail
+// over on network errors and 502, 503 and 504 responses, other requests only
+// when no connection could be established.
+func WithFailover(baseURLs ...string) ClientOption {
+	return func(d *DirectusClient) {
+		for _, s := range baseURLs {
+			u, err := url.Parse(strings.TrimSuffix(s, "/"))
+			if err != nil {
+				d.optErr = err
+				return
+			}
+			d.endpoints.standbys = append(d.endpoints.standbys, &endpoint{url: u})
+		}
+	}
+}
+
+// WithRoundRobin balances reads over the primary and the standbys of
+// WithFailover instead of using them in order. Replicas still take
+// precedence.
+func WithRoundRobin() ClientOption {
+	return func(d *DirectusClient) {
+		d.endpoints.roundRobin = true
+	}
+}
+
+// WithReplicas adds read replicas. GET, HEAD and SEARCH requests are balanced
+// over the healthy replicas and fail over to the next endpoint on network
+// errors and 502, 503 and 504 responses; all other requests go to the primary
+// base URL.
+func WithReplicas(baseURLs ...string) ClientOption {
+	return func(d *DirectusClient) {
+		for _, s := range baseURLs {
+			u, err := url.Parse(strings.TrimSuffix(s, "/"))
+			if err != nil {
+				d.optErr = err
+				return
+			}
+			d.endpoints.replicas = append(d.endpoints.replicas, &endpoint{url: u})
+		}
+	}
+}
+
+// WithHealthCheck polls /server/health of every endpoint at interval and
+// takes endpoints out of rotation while they report unhealthy. Call Close to
+// stop it.
+func WithHealthCheck(interval time.Duration) ClientOption {
+	return func(d *DirectusClient) {
+		d.healthInterval = interval
+	}
+}
+
+// candidates lists the endpoints to try for method in order.
+func (p *endpointPool) candidates(method string) []*endpoint {
+	if len(p.replicas) == 0 && len(p.standbys) == 0 {
+		return []*endpoint{p.primary}
+	}
+	now := time.Now().UnixNano()
+	start := atomic.AddUint32(&p.next, 1)
+	list := make([]*endpoint, 0, len(p.replicas)+len(p.standbys)+1)
+	if isRead(method) {
+		list = appendHealthy(list, p.replicas, start, now)
+	}
+	hosts := append([]*endpoint{p.primary}, p.standbys...)
+	if !isRead(method) || !p.roundRobin {
+		start = 0
+	}
+	list = appendHealthy(list, hosts, start, now)
+	if len(list) == 0 {
+		list = append(list, p.primary)
+	}
+	return list
+}
+
+// appendHealthy appends the healthy endpoints of from, starting at index
+// start modulo its length.
+func appendHealthy(list []*endpoint, from []*endpoint, start uint32, now int64) []*endpoint {
+	n := uint32(len(from))
+	for i := uint32(0); i < n; i++ {
+		if e := from[(start+i)%n]; e.healthy(now) {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+// do sends req to the first endpoint that answers. Reads are sent to the next
+// endpoint on any failure, other requests only if they didn't reach
+// Directus and their body can be replayed.
+func (d *DirectusClient) do(req *http.Request) (*http.Response, error) {
+	candidates := d.endpoints.candidates(req.Method)
+	if primaryOnly(req.Context()) {
+		candidates = []*endpoint{d.endpoints.primary}
+	}
+	for i, e := range candidates {
+		r := req
+		if i > 0 {
+			r = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+		r.URL.Scheme = e.url.Scheme
+		r.URL.Host = e.url.Host
+		r.Host = e.url.Host
+		resp, err := d.client.Do(r)
+		last := i == len(candidates)-1
+		switch {
+		case err != nil:
+			if req.Context().Err() != nil {
+				return nil, err
+			}
+			e.fail()
+			if last || (!isRead(req.Method) && !(isDialError(err) && replayable(req))) {
+				return nil, err
+			}
+		case resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusGatewayTimeout:
+			e.fail()
+			if last || !isRead(req.Method) {
+				return resp, nil
+			}
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+		d.log.Warn("directus endpoint failed, trying next", "error", err, "endpoint", e.url.Host, "request_id", RequestIDFromContext(req.Context()))
+	}
+	return nil, errors.New("no endpoint available")
+}
+
+type primaryOnlyKey struct{}
+
+// withPrimaryOnly makes requests with ctx go to the primary base URL once,
+// without retries or failover, for requests about that endpoint such as
+// Health.
+func withPrimaryOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryOnlyKey{}, true)
+}
+
+func primaryOnly(ctx context.Context) bool {
+	return ctx.Value(primaryOnlyKey{}) != nil
+}
+
+// isRead reports whether method only reads, so it may be served by replicas
+// and sent to another endpoint after any failure.
+func isRead(method string) bool {
+	return method == "GET" || method == "HEAD" || method == "SEARCH"
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// startHealthCheck runs until Close is called.
+func (d *DirectusClient) startHealthCheck() {
+	all := append([]*endpoint{d.endpoints.primary}, d.endpoints.standbys...)
+	all = append(all, d.endpoints.replicas...)
+	go func() {
+		ticker := time.NewTicker(d.healthInterval)
+		defer ticker.Stop()
+		for {
+			for _, e := range all {
+				d.checkHealth(e)
+			}
+			select {
+			case <-d.endpoints.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (d *DirectusClient) checkHealth(e *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.healthInterval)
+	defer cancel()
+	u := *e.url
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/server/health"
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return
+	}
+	healthy := false
+	if resp, err := d.client.Do(req); err == nil {
+		healthy = resp.StatusCode == http.StatusOK
+		resp.Body.Close()
+	}
+	if healthy {
+		atomic.StoreInt32(&e.down, 0)
+		atomic.StoreInt64(&e.downUntil, 0)
+	} else if atomic.SwapInt32(&e.down, 1) == 0 {
+		d.log.Warn("directus endpoint unhealthy", "endpoint", e.url.Host)
+	}
+}
+
+// Close stops background health checks. The client must not be used
+// afterwards.
+func (d *DirectusClient) Close() error {
+	if d.derived {
+		return nil
+	}
+	d.endpoints.stopOnce.Do(func() { close(d.endpoints.stop) })
+	return nil
+}
@@ -0,0 +1,157 @@
+package directus_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type node struct {
+	*httptest.Server
+	hits    int64
+	healthy int32
+}
+
+func newNode(t *testing.T) *node {
+	n := &node{healthy: 1}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/server/health" {
+			if atomic.LoadInt32(&n.healthy) == 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		atomic.AddInt64(&n.hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+func (n *node) Hits() int64 {
+	return atomic.LoadInt64(&n.hits)
+}
+
+func TestReplicas(t *testing.T) {
+	primary, replica1, replica2 := newNode(t), newNode(t), newNode(t)
+	client, err := NewDirectusClient(primary.URL, "token", NewNoopQueryCache(), WithReplicas(replica1.URL, replica2.URL))
+	require.NoError(t, err)
+	defer client.Close()
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 4; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 0, primary.Hits())
+	require.EqualValues(t, 2, replica1.Hits())
+	require.EqualValues(t, 2, replica2.Hits())
+
+	resp, err := client.Query("POST", "user", query, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.EqualValues(t, 1, primary.Hits())
+
+	// reads fail over to the primary once all replicas are gone
+	replica1.Close()
+	replica2.Close()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 3, primary.Hits())
+}
+
+func TestFailover(t *testing.T) {
+	primary, standby := newNode(t), newNode(t)
+	client, err := NewDirectusClient(primary.URL, "token", NewNoopQueryCache(), WithFailover(standby.URL))
+	require.NoError(t, err)
+	defer client.Close()
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 2, primary.Hits())
+	require.EqualValues(t, 0, standby.Hits())
+
+	// writes fail over too when the primary can't be reached
+	down := newNode(t)
+	down.Close()
+	client, err = NewDirectusClient(down.URL, "token", NewNoopQueryCache(), WithFailover(standby.URL))
+	require.NoError(t, err)
+	defer client.Close()
+	resp, err := client.Query("POST", "user", query, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.EqualValues(t, 1, standby.Hits())
+	resp, err = client.Query("PATCH", "user", query, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.EqualValues(t, 2, standby.Hits())
+}
+
+func TestRoundRobin(t *testing.T) {
+	primary, standby := newNode(t), newNode(t)
+	client, err := NewDirectusClient(primary.URL, "token", NewNoopQueryCache(), WithFailover(standby.URL), WithRoundRobin())
+	require.NoError(t, err)
+	defer client.Close()
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 4; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 2, primary.Hits())
+	require.EqualValues(t, 2, standby.Hits())
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("POST", "user", query, strings.NewReader(`{}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 4, primary.Hits())
+}
+
+func TestHealthCheck(t *testing.T) {
+	primary, replica := newNode(t), newNode(t)
+	atomic.StoreInt32(&replica.healthy, 0)
+	client, err := NewDirectusClient(primary.URL, "token", NewNoopQueryCache(), WithReplicas(replica.URL), WithHealthCheck(10*time.Millisecond))
+	require.NoError(t, err)
+	defer client.Close()
+
+	get := func() {
+		resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&client.endpoints.replicas[0].down) == 1
+	}, time.Second, 5*time.Millisecond)
+	get()
+	require.EqualValues(t, 1, primary.Hits())
+	require.EqualValues(t, 0, replica.Hits())
+
+	atomic.StoreInt32(&replica.healthy, 1)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&client.endpoints.replicas[0].down) == 0
+	}, time.Second, 5*time.Millisecond)
+	get()
+	require.EqualValues(t, 1, replica.Hits())
+}
+
+func TestReplicasInvalidURL(t *testing.T) {
+	_, err := NewDirectusClient("http://localhost", "token", NewNoopQueryCache(), WithReplicas("http://[::1"))
+	require.Error(t, err)
+}
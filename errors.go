@@ -0,0 +1,123 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinels matched by errors.Is against APIError, by status code or Directus
+// error code. Note that Directus answers requests for missing items with 403
+// FORBIDDEN, so they don't reveal whether an item exists; only unknown routes
+// are ErrNotFound.
+var (
+	ErrNotFound     = errors.New("directus: not found")
+	ErrForbidden    = errors.New("directus: forbidden")
+	ErrUnauthorized = errors.New("directus: unauthorized")
+	ErrRateLimited  = errors.New("directus: rate limited")
+	ErrValidation   = errors.New("directus: validation failed")
+)
+
+// errorCodes maps Directus error codes to sentinels.
+var errorCodes = map[string]error{
+	"ROUTE_NOT_FOUND":      ErrNotFound,
+	"FORBIDDEN":            ErrForbidden,
+	"INVALID_CREDENTIALS":  ErrUnauthorized,
+	"INVALID_TOKEN":        ErrUnauthorized,
+	"TOKEN_EXPIRED":        ErrUnauthorized,
+	"INVALID_OTP":          ErrUnauthorized,
+	"REQUESTS_EXCEEDED":    ErrRateLimited,
+	"FAILED_VALIDATION":    ErrValidation,
+	"INVALID_PAYLOAD":      ErrValidation,
+	"INVALID_QUERY":        ErrValidation,
+	"RECORD_NOT_UNIQUE":    ErrValidation,
+	"CONTAINS_NULL_VALUES": ErrValidation,
+	"NOT_NULL_VIOLATION":   ErrValidation,
+	"VALUE_OUT_OF_RANGE":   ErrValidation,
+	"VALUE_TOO_LONG":       ErrValidation,
+	"INVALID_FOREIGN_KEY":  ErrValidation,
+}
+
+// statusErrors maps status codes to sentinels, for errors without a known
+// code.
+var statusErrors = map[int]error{
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusUnprocessableEntity: ErrValidation,
+}
+
+// ErrorExtensions are the details Directus adds to an error. Field and
+// Collection are set for validation and uniqueness errors, Type is the
+// failed validation rule, e.g. "nnull" or "regex".
+type ErrorExtensions struct {
+	Code       string `json:"code,omitempty"`
+	Collection string `json:"collection,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Type       string `json:"type,omitempty"`
+}
+
+// APIError is a response of Directus with an error status. It is returned by
+// the typed APIs, wrapped in a RequestError where a request id is known, so
+// use errors.As to inspect it.
+type APIError struct {
+	StatusCode int
+	Status     string
+	// Errors reported by Directus, empty if the body had none, e.g. for
+	// errors of a proxy in front of Directus.
+	Errors []DirectusError
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("directus: %s: %s", e.Status, e.Errors[0].Message)
+	}
+	return fmt.Sprintf("directus: %s", e.Status)
+}
+
+// Code returns the code of the first error, e.g. "FORBIDDEN" or
+// "RECORD_NOT_UNIQUE", empty if there is none.
+func (e *APIError) Code() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Extensions.Code
+}
+
+// Fields returns the fields the errors refer to, in order and without
+// duplicates, e.g. the fields that failed validation.
+func (e *APIError) Fields() []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, de := range e.Errors {
+		if f := de.Extensions.Field; f != "" && !seen[f] {
+			seen[f] = true
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Is matches the sentinel of the code of any error, or of the status code.
+func (e *APIError) Is(target error) bool {
+	for _, de := range e.Errors {
+		if errorCodes[de.Extensions.Code] == target {
+			return true
+		}
+	}
+	return statusErrors[e.StatusCode] == target && target != nil
+}
+
+// responseError describes a failed request with the errors reported by
+// Directus, if any.
+func responseError(resp *http.Response, body io.Reader) error {
+	e := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+	var result DirectusResult[json.RawMessage]
+	if err := decodeJSON(io.LimitReader(body, 64<<10), &result); err == nil {
+		e.Errors = result.Errors
+	}
+	return e
+}
@@ -0,0 +1,68 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[
+			{"message":"Value for field \"email\" in collection \"users\" has to be unique.","extensions":{"code":"RECORD_NOT_UNIQUE","collection":"users","field":"email"}},
+			{"message":"Validation failed for field \"title\".","extensions":{"code":"FAILED_VALIDATION","field":"title","type":"nnull"}},
+			{"message":"Validation failed for field \"title\".","extensions":{"code":"FAILED_VALIDATION","field":"title","type":"regex"}}
+		]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	_, err = Items[article](client, "articles").Create(context.Background(), article{Title: "a"})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal(t, "RECORD_NOT_UNIQUE", apiErr.Code())
+	require.Equal(t, []string{"email", "title"}, apiErr.Fields())
+	require.Equal(t, "nnull", apiErr.Errors[1].Extensions.Type)
+	require.Equal(t, `directus: 400 Bad Request: Value for field "email" in collection "users" has to be unique.`, err.Error())
+
+	require.Equal(t, "directus: 502 Bad Gateway", (&APIError{StatusCode: 502, Status: "502 Bad Gateway"}).Error())
+	require.Empty(t, (&APIError{}).Code())
+}
+
+func TestSentinelErrors(t *testing.T) {
+	for _, tt := range []struct {
+		status int
+		code   string
+		want   error
+	}{
+		{http.StatusNotFound, "ROUTE_NOT_FOUND", ErrNotFound},
+		{http.StatusForbidden, "FORBIDDEN", ErrForbidden},
+		{http.StatusUnauthorized, "TOKEN_EXPIRED", ErrUnauthorized},
+		{http.StatusTooManyRequests, "REQUESTS_EXCEEDED", ErrRateLimited},
+		{http.StatusBadRequest, "FAILED_VALIDATION", ErrValidation},
+		{http.StatusBadRequest, "RECORD_NOT_UNIQUE", ErrValidation},
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusUnauthorized, "", ErrUnauthorized},
+	} {
+		e := &APIError{StatusCode: tt.status, Status: http.StatusText(tt.status)}
+		if tt.code != "" {
+			e.Errors = []DirectusError{{Message: "failed", Extensions: ErrorExtensions{Code: tt.code}}}
+		}
+		err := error(&RequestError{"id", e})
+		require.ErrorIs(t, err, tt.want, tt.code)
+		for _, other := range []error{ErrNotFound, ErrForbidden, ErrUnauthorized, ErrRateLimited, ErrValidation} {
+			if other != tt.want {
+				require.False(t, errors.Is(err, other), "%s is %s", tt.code, other)
+			}
+		}
+	}
+	require.False(t, errors.Is(&APIError{StatusCode: http.StatusInternalServerError}, ErrNotFound))
+}
@@ -0,0 +1,168 @@
+package directus_client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ExportFormat selects how QueryTo writes a result.
+type ExportFormat int
+
+const (
+	// ExportRaw copies the response body as served, envelope included.
+	ExportRaw ExportFormat = iota
+	// ExportJSON writes the data array only.
+	ExportJSON
+	// ExportNDJSON writes one item per line.
+	ExportNDJSON
+	// ExportCSV has Directus render the items as CSV.
+	ExportCSV
+	// ExportXML has Directus render the items as XML.
+	ExportXML
+	// ExportYAML has Directus render the items as YAML.
+	ExportYAML
+)
+
+// FileFormat is a format Directus renders items in for download, set by
+// DirectusQuery.Export.
+type FileFormat string
+
+const (
+	FileCSV  FileFormat = "csv"
+	FileJSON FileFormat = "json"
+	FileXML  FileFormat = "xml"
+	FileYAML FileFormat = "yaml"
+)
+
+func (f FileFormat) valid() bool {
+	switch f {
+	case FileCSV, FileJSON, FileXML, FileYAML:
+		return true
+	}
+	return false
+}
+
+// serverFormats are the formats of QueryTo rendered by Directus.
+var serverFormats = map[ExportFormat]FileFormat{
+	ExportCSV:  FileCSV,
+	ExportXML:  FileXML,
+	ExportYAML: FileYAML,
+}
+
+// Export streams the items matching query to w as rendered by Directus in
+// the format of query.Export, JSON if unset, without decoding them, e.g. for
+// reporting jobs writing files.
+func (d *DirectusClient) Export(ctx context.Context, collection string, query DirectusQuery, w io.Writer) error {
+	if query.Export == "" {
+		query.Export = FileJSON
+	}
+	return d.QueryTo(ctx, collection, query, w, ExportRaw)
+}
+
+// QueryTo streams the items matching query to w without holding the whole
+// result in memory, for export handlers and file generation from large
+// collections. JSON formats are re-encoded one item at a time. A configured
+// cache still buffers the response in order to store it.
+func (d *DirectusClient) QueryTo(ctx context.Context, collection string, query DirectusQuery, w io.Writer, format ExportFormat) error {
+	if err := d.checkQuery(&query); err != nil {
+		return err
+	}
+	v, err := query.BuildQuery()
+	if err != nil {
+		return err
+	}
+	if d.bracketFilters {
+		if err := bracketFilter(v); err != nil {
+			return err
+		}
+	}
+	if f, ok := serverFormats[format]; ok {
+		v.Set("export", string(f))
+	}
+	resp, err := d.CallContext(ctx, d.newItemsRequest(ctx, "GET", collection, v, nil))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := responseBody(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp, body)
+	}
+
+	switch format {
+	case ExportRaw, ExportCSV, ExportXML, ExportYAML:
+		_, err = io.Copy(w, body)
+		return err
+	case ExportJSON, ExportNDJSON:
+		return writeItems(w, body, format == ExportNDJSON)
+	}
+	return errors.New("unknown export format")
+}
+
+// writeItems copies the elements of the data array of a Directus response to
+// w, decoding a single item at a time.
+func writeItems(w io.Writer, r io.Reader, lines bool) error {
+	dec := json.NewDecoder(r)
+	if err := seekData(dec); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if !lines {
+		bw.WriteByte('[')
+	}
+	var item json.RawMessage
+	for i := 0; dec.More(); i++ {
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		if i > 0 && !lines {
+			bw.WriteByte(',')
+		}
+		bw.Write(item)
+		if lines {
+			bw.WriteByte('\n')
+		}
+	}
+	if !lines {
+		bw.WriteByte(']')
+	}
+	return bw.Flush()
+}
+
+// seekData advances dec to the first element of the top level data array.
+func seekData(dec *json.Decoder) error {
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t != json.Delim('{') {
+		return errors.New("response is not an object")
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if t != "data" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		t, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if t != json.Delim('[') {
+			return errors.New("data is not an array")
+		}
+		return nil
+	}
+	return errors.New("response has no data")
+}
@@ -0,0 +1,81 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/directustest"
+)
+
+func TestQueryTo(t *testing.T) {
+	client, srv := createClient(t, NewNoopQueryCache())
+	srv.AddFixture(
+		directustest.Fixture{
+			Collection: "user",
+			Query:      map[string]string{"filter": `{"id":{"_gt":0}}`},
+			Body:       json.RawMessage(`{"meta":{"total_count":2},"data":[{"id":1,"email":"dev@dev.io"},{"id":2,"email":"ops@dev.io"}]}`),
+		},
+		directustest.Fixture{
+			Collection: "user",
+			Query:      map[string]string{"export": "csv"},
+			Body:       json.RawMessage(`"id,email\n1,dev@dev.io\n"`),
+		},
+		directustest.Fixture{
+			Collection: "missing",
+			Status:     403,
+			Body:       json.RawMessage(`{"errors":[{"message":"You don't have permission to access this."}]}`),
+		},
+	)
+	query := DirectusQuery{Filter: Filter{"id": {OP_gt: 0}}}
+
+	for format, want := range map[ExportFormat]string{
+		ExportRaw:    `{"meta":{"total_count":2},"data":[{"id":1,"email":"dev@dev.io"},{"id":2,"email":"ops@dev.io"}]}`,
+		ExportJSON:   `[{"id":1,"email":"dev@dev.io"},{"id":2,"email":"ops@dev.io"}]`,
+		ExportNDJSON: "{\"id\":1,\"email\":\"dev@dev.io\"}\n{\"id\":2,\"email\":\"ops@dev.io\"}\n",
+		ExportCSV:    `"id,email\n1,dev@dev.io\n"`,
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, client.QueryTo(context.Background(), "user", query, &buf, format))
+		require.Equal(t, want, buf.String())
+	}
+
+	err := client.QueryTo(context.Background(), "missing", query, new(bytes.Buffer), ExportJSON)
+	require.EqualError(t, err, "directus: 403 Forbidden: You don't have permission to access this.")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, client.QueryTo(ctx, "user", query, new(bytes.Buffer), ExportJSON), context.Canceled)
+}
+
+func TestExport(t *testing.T) {
+	var exports []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exports = append(exports, r.URL.Query().Get("export"))
+		w.Header().Set("Content-Type", "text/yaml")
+		w.Write([]byte("- id: 1\n  email: dev@dev.io\n"))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	query := DirectusQuery{Filter: Filter{"id": {OP_gt: 0}}, Export: FileYAML}
+
+	var buf bytes.Buffer
+	require.NoError(t, client.Export(context.Background(), "user", query, &buf))
+	require.Equal(t, "- id: 1\n  email: dev@dev.io\n", buf.String())
+	query.Export = ""
+	require.NoError(t, client.Export(context.Background(), "user", query, new(bytes.Buffer)))
+	require.NoError(t, client.QueryTo(context.Background(), "user", query, new(bytes.Buffer), ExportXML))
+	require.Equal(t, []string{"yaml", "json", "xml"}, exports)
+
+	parsed, err := ParseQuery(url.Values{"filter": {"{}"}, "export": {"xml"}})
+	require.NoError(t, err)
+	require.Equal(t, FileXML, parsed.Export)
+	_, err = ParseQuery(url.Values{"filter": {"{}"}, "export": {"pdf"}})
+	require.Error(t, err)
+}
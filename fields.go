@@ -0,0 +1,49 @@
+package directus_client
+
+import "strings"
+
+// FieldPath is a relational field for building projections, e.g.
+// Field("author").Select("id", "name") for "author.id,author.name".
+type FieldPath string
+
+// Field starts a path at a relational field.
+func Field(name string) FieldPath {
+	return FieldPath(name)
+}
+
+// Field returns the path of the related field name.
+func (p FieldPath) Field(name string) FieldPath {
+	return p + "." + FieldPath(name)
+}
+
+// Select returns the given fields of the related items, all of them if none
+// are given.
+func (p FieldPath) Select(fields ...string) Fields {
+	if len(fields) == 0 {
+		return Fields{string(p) + ".*"}
+	}
+	out := make(Fields, len(fields))
+	for i, f := range fields {
+		out[i] = string(p) + "." + f
+	}
+	return out
+}
+
+// Wildcard selects all fields down to depth levels of relations, e.g. "*.*"
+// for depth 2. Depths below 1 select the fields of the items only.
+func Wildcard(depth int) string {
+	if depth < 1 {
+		depth = 1
+	}
+	return strings.TrimSuffix(strings.Repeat("*.", depth), ".")
+}
+
+// With returns f followed by more, e.g.
+// Fields{"id", "title"}.With(Field("author").Select("name")).
+func (f Fields) With(more ...Fields) Fields {
+	out := append(Fields(nil), f...)
+	for _, m := range more {
+		out = append(out, m...)
+	}
+	return out
+}
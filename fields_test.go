@@ -0,0 +1,23 @@
+package directus_client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsBuilder(t *testing.T) {
+	fields := Fields{"id", "title"}.With(
+		Field("author").Select("id", "name"),
+		Field("author").Field("role").Select(),
+		Field("tags").Select("tags_id.name"),
+	)
+	require.Equal(t, Fields{"id", "title", "author.id", "author.name", "author.role.*", "tags.tags_id.name"}, fields)
+	require.Equal(t, "*", Wildcard(0))
+	require.Equal(t, "*.*.*", Wildcard(3))
+
+	q := DirectusQuery{Filter: Filter{}, Fields: fields.With(Fields{Wildcard(2)})}
+	require.NoError(t, q.validate())
+	q.Fields = Field("author").Select("", "na me")
+	require.EqualError(t, q.validate(), `invalid fields entry: "author."`)
+}
@@ -0,0 +1,69 @@
+package directus_client
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// DirectusFile is the record of a file in Directus. Width and Height are set
+// for images, Duration for audio and video, in seconds.
+type DirectusFile struct {
+	ID               string         `json:"id,omitempty"`
+	Storage          string         `json:"storage,omitempty"`
+	FilenameDisk     string         `json:"filename_disk,omitempty"`
+	FilenameDownload string         `json:"filename_download,omitempty"`
+	Title            string         `json:"title,omitempty"`
+	Type             string         `json:"type,omitempty"`
+	Folder           string         `json:"folder,omitempty"`
+	UploadedBy       string         `json:"uploaded_by,omitempty"`
+	UploadedOn       *time.Time     `json:"uploaded_on,omitempty"`
+	ModifiedBy       string         `json:"modified_by,omitempty"`
+	ModifiedOn       *time.Time     `json:"modified_on,omitempty"`
+	Charset          string         `json:"charset,omitempty"`
+	Filesize         FileSize       `json:"filesize,omitempty"`
+	Width            int            `json:"width,omitempty"`
+	Height           int            `json:"height,omitempty"`
+	Duration         int            `json:"duration,omitempty"`
+	Embed            string         `json:"embed,omitempty"`
+	Description      string         `json:"description,omitempty"`
+	Location         string         `json:"location,omitempty"`
+	Tags             []string       `json:"tags,omitempty"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
+}
+
+// FileSize is a size in bytes. Directus sends sizes beyond the range of
+// JavaScript numbers as strings, depending on the database, so both are
+// accepted.
+type FileSize int64
+
+func (s *FileSize) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+	if string(b) == "null" || len(b) == 0 {
+		*s = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return err
+	}
+	*s = FileSize(n)
+	return nil
+}
+
+// FileUpdate is a patch of the metadata of a file for Files().Update; nil
+// fields are left as they are.
+type FileUpdate struct {
+	Title            *string   `json:"title,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	FilenameDownload *string   `json:"filename_download,omitempty"`
+	Folder           *string   `json:"folder,omitempty"`
+	Tags             *[]string `json:"tags,omitempty"`
+	Location         *string   `json:"location,omitempty"`
+}
+
+// Files returns a client for the file records of Directus at /files. Deleting
+// a record deletes the file from storage too.
+func (d *DirectusClient) Files() *SystemClient[DirectusFile] {
+	return System[DirectusFile](d, "/files")
+}
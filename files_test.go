@@ -0,0 +1,50 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /files":
+			require.JSONEq(t, `{"type":{"_starts_with":"image/"}}`, r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"data":[
+				{"id":"f1","type":"image/png","filesize":"5368709120","width":800,"height":600,"tags":["logo"]},
+				{"id":"f2","type":"image/jpeg","filesize":1024,"width":null}
+			]}`))
+		case "PATCH /files/f1":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, map[string]any{"title": "Logo", "tags": []any{}, "folder": "fo1"}, body)
+			w.Write([]byte(`{"data":{"id":"f1","title":"Logo","folder":"fo1","filesize":1}}`))
+		case "DELETE /files/f1":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	files, _, err := client.Files().List(ctx, DirectusQuery{Filter: Filter{"type": {OP_starts_with: "image/"}}})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, FileSize(5<<30), files[0].Filesize)
+	require.Equal(t, 800, files[0].Width)
+	require.Equal(t, []string{"logo"}, files[0].Tags)
+	require.Equal(t, FileSize(1024), files[1].Filesize)
+
+	title, folder, tags := "Logo", "fo1", []string{}
+	f, err := client.Files().Update(ctx, "f1", FileUpdate{Title: &title, Folder: &folder, Tags: &tags})
+	require.NoError(t, err)
+	require.Equal(t, "Logo", f.Title)
+	require.NoError(t, client.Files().Delete(ctx, "f1"))
+}
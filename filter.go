@@ -0,0 +1,380 @@
+package directus_client
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FilterNode is a filter expression: a Filter of field conditions, or a
+// group of expressions combined with And or Or, nested arbitrarily. Fields of
+// related items are addressed by dotted paths, e.g. "author.role.name", which
+// are sent as the nested objects Directus expects:
+//
+//	Filter{"author.role.name": {OP_eq: "editor"}}
+//
+// becomes {"author":{"role":{"name":{"_eq":"editor"}}}}. Groups look like
+//
+//	Or{
+//		Filter{"status": {OP_eq: "published"}},
+//		And{Filter{"status": {OP_eq: "draft"}}, Filter{"author": {OP_eq: "$CURRENT_USER"}}},
+//	}
+type FilterNode interface {
+	filterNode()
+}
+
+func (Filter) filterNode() {}
+
+// And matches items matching all of its filters, encoded as {"_and": [...]}.
+type And []FilterNode
+
+func (And) filterNode() {}
+
+func (a And) MarshalJSON() ([]byte, error) {
+	return marshalGroup("_and", a)
+}
+
+// Or matches items matching any of its filters, encoded as {"_or": [...]}.
+type Or []FilterNode
+
+func (Or) filterNode() {}
+
+func (o Or) MarshalJSON() ([]byte, error) {
+	return marshalGroup("_or", o)
+}
+
+func marshalGroup(op string, nodes []FilterNode) ([]byte, error) {
+	return currentCodec().Marshal(groupJSON(op, nodes))
+}
+
+// filterJSON returns f in the shape Directus expects, with dotted paths
+// expanded into nested objects.
+func filterJSON(f FilterNode) any {
+	switch f := f.(type) {
+	case Filter:
+		return f.expand()
+	case And:
+		return groupJSON("_and", f)
+	case Or:
+		return groupJSON("_or", f)
+	}
+	return f
+}
+
+func groupJSON(op string, nodes []FilterNode) map[string][]any {
+	items := make([]any, 0, len(nodes))
+	for _, n := range nodes {
+		items = append(items, filterJSON(n))
+	}
+	return map[string][]any{op: items}
+}
+
+// expand nests the conditions of dotted paths; filters without any are
+// returned as they are.
+func (f Filter) expand() any {
+	nested := false
+	for path := range f {
+		if strings.Contains(path, ".") {
+			nested = true
+			break
+		}
+	}
+	if !nested {
+		return f
+	}
+	tree := make(map[string]any, len(f))
+	for path, ops := range f {
+		node := tree
+		for _, segment := range strings.Split(path, ".") {
+			child, ok := node[segment].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[segment] = child
+			}
+			node = child
+		}
+		for op, v := range ops {
+			node[string(op)] = v
+		}
+	}
+	return tree
+}
+
+// filterEmpty reports whether f has no conditions, matching every item.
+func filterEmpty(f FilterNode) bool {
+	switch f := f.(type) {
+	case Filter:
+		for _, ops := range f {
+			if len(ops) > 0 {
+				return false
+			}
+		}
+	case And:
+		for _, n := range f {
+			if !filterEmpty(n) {
+				return false
+			}
+		}
+	case Or:
+		for _, n := range f {
+			if !filterEmpty(n) {
+				return false
+			}
+		}
+	case nil:
+	default:
+		return false
+	}
+	return true
+}
+
+// parseFilter decodes the JSON filter parameter. Field conditions of an
+// object become a Filter, its _and and _or groups And and Or nodes, combined
+// with And if an object has several of them.
+func parseFilter(data []byte) (FilterNode, error) {
+	var obj map[string]any
+	if err := currentCodec().Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return filterFromJSON(obj)
+}
+
+func filterFromJSON(obj map[string]any) (FilterNode, error) {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := Filter{}
+	var groups []FilterNode
+	for _, k := range keys {
+		switch k {
+		case "_and", "_or":
+			items, ok := obj[k].([]any)
+			if !ok {
+				return nil, errors.New("filter " + k + " must be an array")
+			}
+			nodes := make([]FilterNode, 0, len(items))
+			for _, item := range items {
+				o, ok := item.(map[string]any)
+				if !ok {
+					return nil, errors.New("filter " + k + " must be an array of objects")
+				}
+				n, err := filterFromJSON(o)
+				if err != nil {
+					return nil, err
+				}
+				nodes = append(nodes, n)
+			}
+			if k == "_and" {
+				groups = append(groups, And(nodes))
+			} else {
+				groups = append(groups, Or(nodes))
+			}
+		default:
+			if !validFieldName(k) {
+				return nil, errors.New("invalid filter field: " + strconv.Quote(k))
+			}
+			if err := flattenField(fields, k, obj[k]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	switch {
+	case len(groups) == 0:
+		return fields, nil
+	case len(fields) == 0 && len(groups) == 1:
+		return groups[0], nil
+	case len(fields) == 0:
+		return And(groups), nil
+	}
+	return append(And{fields}, groups...), nil
+}
+
+// flattenField adds the conditions on path to fields, those on fields of
+// related items, i.e. keys not starting with "_", under dotted paths.
+func flattenField(fields Filter, path string, v any) error {
+	o, ok := v.(map[string]any)
+	if !ok {
+		return errors.New("filter of " + strconv.Quote(path) + " must be an object")
+	}
+	if len(o) == 0 && fields[path] == nil {
+		fields[path] = map[FilterOperator]any{}
+	}
+	for k, v := range o {
+		if strings.HasPrefix(k, "_") {
+			if fields[path] == nil {
+				fields[path] = make(map[FilterOperator]any)
+			}
+			fields[path][FilterOperator(k)] = v
+			continue
+		}
+		sub := path + "." + k
+		if !validFieldName(sub) {
+			return errors.New("invalid filter field: " + strconv.Quote(sub))
+		}
+		if err := flattenField(fields, sub, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operatorArity is the kind of value a filter operator takes.
+type operatorArity int
+
+const (
+	arityScalar operatorArity = iota
+	arityList
+	arityPair
+	arityBool
+	arityFilter
+	arityGeometry
+)
+
+// filterOperators are the operators Directus knows.
+var filterOperators = map[FilterOperator]operatorArity{
+	OP_eq:               arityScalar,
+	OP_neq:              arityScalar,
+	OP_lt:               arityScalar,
+	OP_lte:              arityScalar,
+	OP_gt:               arityScalar,
+	OP_gte:              arityScalar,
+	OP_in:               arityList,
+	OP_nin:              arityList,
+	OP_null:             arityBool,
+	OP_nnull:            arityBool,
+	OP_contains:         arityScalar,
+	OP_ncontains:        arityScalar,
+	OP_icontains:        arityScalar,
+	OP_nicontains:       arityScalar,
+	OP_starts_with:      arityScalar,
+	OP_nstarts_with:     arityScalar,
+	OP_istarts_with:     arityScalar,
+	OP_nistarts_with:    arityScalar,
+	OP_ends_with:        arityScalar,
+	OP_nends_with:       arityScalar,
+	OP_iends_with:       arityScalar,
+	OP_niends_with:      arityScalar,
+	OP_between:          arityPair,
+	OP_nbetween:         arityPair,
+	OP_empty:            arityBool,
+	OP_nempty:           arityBool,
+	"_regex":            arityScalar,
+	OP_intersects:       arityGeometry,
+	OP_nintersects:      arityGeometry,
+	OP_intersects_bbox:  arityGeometry,
+	OP_nintersects_bbox: arityGeometry,
+	"_some":             arityFilter,
+	"_none":             arityFilter,
+}
+
+// validateFilter checks that f only uses known operators with values they
+// accept, reporting the path of the first offending condition.
+func validateFilter(f FilterNode, path string) error {
+	switch f := f.(type) {
+	case Filter:
+		fields := make([]string, 0, len(f))
+		for field := range f {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			p := joinPath(path, field)
+			if !validFieldName(field) {
+				return errors.New("filter " + p + ": invalid field name")
+			}
+			ops := make([]string, 0, len(f[field]))
+			for op := range f[field] {
+				ops = append(ops, string(op))
+			}
+			sort.Strings(ops)
+			for _, op := range ops {
+				if err := validateCondition(FilterOperator(op), f[field][FilterOperator(op)], p); err != nil {
+					return err
+				}
+			}
+		}
+	case And:
+		return validateGroup("_and", f, path)
+	case Or:
+		return validateGroup("_or", f, path)
+	}
+	return nil
+}
+
+func validateGroup(op string, nodes []FilterNode, path string) error {
+	for i, n := range nodes {
+		if n == nil {
+			return errors.New("filter " + joinPath(path, op) + "[" + strconv.Itoa(i) + "]: missing filter")
+		}
+		if err := validateFilter(n, joinPath(path, op)+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateCondition(op FilterOperator, v any, path string) error {
+	arity, ok := filterOperators[op]
+	if !ok {
+		return errors.New("filter " + path + ": unknown operator " + strconv.Quote(string(op)))
+	}
+	fail := func(msg string) error {
+		return errors.New("filter " + path + ": " + string(op) + " " + msg)
+	}
+	rv := reflect.ValueOf(v)
+	isList := rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+	switch arity {
+	case arityScalar:
+		if isList || rv.Kind() == reflect.Map {
+			return fail("takes a single value")
+		}
+	case arityList:
+		if _, csv := v.(string); !isList && !csv {
+			return fail("takes a list of values")
+		}
+	case arityPair:
+		if s, csv := v.(string); csv {
+			if strings.Count(s, ",") != 1 {
+				return fail("takes two values")
+			}
+		} else if !isList || rv.Len() != 2 {
+			return fail("takes two values")
+		}
+	case arityBool:
+		switch v {
+		case true, false, "true", "false":
+		default:
+			return fail("takes true or false")
+		}
+	case arityFilter:
+		switch v := v.(type) {
+		case FilterNode:
+			return validateFilter(v, joinPath(path, string(op)))
+		case map[string]any:
+			n, err := filterFromJSON(v)
+			if err != nil {
+				return fail(err.Error())
+			}
+			return validateFilter(n, joinPath(path, string(op)))
+		default:
+			return fail("takes a filter")
+		}
+	case arityGeometry:
+		if !validGeometry(v) {
+			return fail("takes a GeoJSON geometry")
+		}
+	}
+	return nil
+}
+
+func joinPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
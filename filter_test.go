@@ -0,0 +1,144 @@
+package directus_client
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestFilterGroups(t *testing.T) {
+	filter := Or{
+		Filter{"status": {OP_eq: "published"}},
+		And{Filter{"status": {OP_eq: "draft"}}, Filter{"views": {OP_gt: float64(10)}}},
+	}
+	query := DirectusQuery{Filter: filter, Limit: 10}
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"_or":[{"status":{"_eq":"published"}},{"_and":[{"status":{"_eq":"draft"}},{"views":{"_gt":10}}]}]}`, v.Get("filter"))
+
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, FilterNode(filter), parsed.Filter)
+
+	// fields next to groups are combined with them
+	parsed, err = ParseQuery(url.Values{"filter": {`{"title":{"_nnull":true},"_or":[{"id":{"_eq":1}}]}`}})
+	require.NoError(t, err)
+	require.Equal(t, And{Filter{"title": {OP_nnull: true}}, Or{Filter{"id": {OP_eq: float64(1)}}}}, parsed.Filter)
+
+	for _, bad := range []string{`{"_or":{"id":{"_eq":1}}}`, `{"_and":[1]}`, `{"id":1}`, `{"_or":[{"a b":{"_eq":1}}]}`} {
+		_, err = ParseQuery(url.Values{"filter": {bad}})
+		require.Error(t, err, bad)
+	}
+
+	e := emulator.New("token")
+	e.Seed("articles",
+		map[string]any{"title": "a", "status": "published"},
+		map[string]any{"title": "b", "status": "draft", "views": 20},
+		map[string]any{"title": "c", "status": "draft", "views": 5},
+	)
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	list, _, err := Items[article](client, "articles").List(context.Background(), query)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "b", list[1].Title)
+
+	require.True(t, filterEmpty(And{Filter{}, Or{}}))
+	require.False(t, filterEmpty(And{Filter{}, Or{Filter{"id": {OP_eq: 1}}}}))
+}
+
+func TestRelationalFilter(t *testing.T) {
+	filter := Filter{
+		"author.role.name": {OP_eq: "editor"},
+		"author.name":      {OP_starts_with: "A"},
+		"status":           {OP_eq: "published"},
+	}
+	query := DirectusQuery{Filter: Or{filter, Filter{"comments._some.status": {OP_eq: "approved"}}}, Limit: 10}
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"_or":[
+		{"author":{"name":{"_starts_with":"A"},"role":{"name":{"_eq":"editor"}}},"status":{"_eq":"published"}},
+		{"comments":{"_some":{"status":{"_eq":"approved"}}}}
+	]}`, v.Get("filter"))
+
+	parsed, err := ParseQuery(url.Values{"filter": {`{"author":{"_nnull":true,"role":{"name":{"_eq":"editor"}}},"tags":{}}`}})
+	require.NoError(t, err)
+	require.Equal(t, Filter{
+		"author":           {OP_nnull: true},
+		"author.role.name": {OP_eq: "editor"},
+		"tags":             {},
+	}, parsed.Filter)
+
+	_, err = ParseQuery(url.Values{"filter": {`{"author":{"na me":{"_eq":1}}}`}})
+	require.Error(t, err)
+}
+
+func TestValidateFilter(t *testing.T) {
+	valid := Or{
+		Filter{
+			"status":           {OP_in: []string{"draft", "published"}},
+			"views":            {OP_between: []int{1, 10}},
+			"title":            {OP_nnull: true, "_icontains": "go"},
+			"author.role.name": {OP_eq: "editor"},
+			"comments":         {"_some": Filter{"status": {OP_eq: "approved"}}},
+		},
+		And{Filter{"date_created": {OP_gte: NowOffset("-1 week")}}},
+	}
+	require.NoError(t, validateFilter(valid, ""))
+
+	for want, filter := range map[string]FilterNode{
+		`filter status: unknown operator "_equals"`:               Filter{"status": {"_equals": "draft"}},
+		"filter views: _between takes two values":                 Filter{"views": {OP_between: []int{1}}},
+		"filter author.name: _null takes true or false":           Filter{"author.name": {OP_null: "yes"}},
+		"filter _or[1]._and[0].id: _in takes a list of values":    Or{Filter{}, And{Filter{"id": {OP_in: 1}}}},
+		"filter tags: _eq takes a single value":                   Filter{"tags": {OP_eq: []string{"a"}}},
+		`filter comments._some.status: unknown operator "_equal"`: Filter{"comments": {"_some": Filter{"status": {"_equal": 1}}}},
+		"filter _and[0]: missing filter":                          And{nil},
+	} {
+		require.EqualError(t, validateFilter(filter, ""), want)
+	}
+
+	// proxied queries are checked as well
+	_, err := ParseQuery(url.Values{"filter": {`{"comments":{"_some":{"status":{"_between":[1,2,3]}}}}`}})
+	require.EqualError(t, err, "filter comments._some.status: _between takes two values")
+	_, err = ParseQuery(url.Values{"filter": {`{"views":{"_between":"1,10"},"id":{"_in":"1,2"}}`}})
+	require.NoError(t, err)
+}
+
+func TestCaseInsensitiveOperators(t *testing.T) {
+	e := emulator.New("token")
+	e.Seed("articles",
+		map[string]any{"title": "Directus Guide"},
+		map[string]any{"title": "go tips"},
+	)
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	for op, want := range map[FilterOperator]string{
+		OP_icontains:     "Directus Guide",
+		OP_nicontains:    "go tips",
+		OP_istarts_with:  "Directus Guide",
+		OP_nistarts_with: "go tips",
+		OP_iends_with:    "Directus Guide",
+		OP_niends_with:   "go tips",
+	} {
+		arg := "GUIDE"
+		if op == OP_istarts_with || op == OP_nistarts_with {
+			arg = "dIrEcTuS"
+		}
+		list, _, err := articles.List(ctx, DirectusQuery{Filter: Filter{"title": {op: arg}}})
+		require.NoError(t, err, op)
+		require.Len(t, list, 1, op)
+		require.Equal(t, want, list[0].Title, op)
+	}
+}
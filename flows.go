@@ -0,0 +1,70 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Flow is a Directus flow, an automation started by Trigger: "event",
+// "webhook", "schedule", "operation" or "manual". Operation is the id of
+// its first operation and Options the configuration of its trigger.
+type Flow struct {
+	ID             string          `json:"id,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	Icon           string          `json:"icon,omitempty"`
+	Color          string          `json:"color,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	Trigger        string          `json:"trigger,omitempty"`
+	Accountability string          `json:"accountability,omitempty"`
+	Options        json.RawMessage `json:"options,omitempty"`
+	Operation      string          `json:"operation,omitempty"`
+	Operations     []string        `json:"operations,omitempty"`
+	DateCreated    *time.Time      `json:"date_created,omitempty"`
+	UserCreated    string          `json:"user_created,omitempty"`
+}
+
+// Flows returns a client for the flows of Directus at /flows.
+func (d *DirectusClient) Flows() *SystemClient[Flow] {
+	return System[Flow](d, "/flows")
+}
+
+// TriggerFlow runs the webhook flow with flowID, POSTing payload as JSON, or
+// with a GET if payload is nil as flows configured for GET expect, and
+// decodes what the flow returns into out, if not nil. Flows return their
+// result as it is, not as data, and nothing unless configured to.
+func (d *DirectusClient) TriggerFlow(ctx context.Context, flowID string, payload any, out any) error {
+	path, err := systemPath("/flows/trigger", flowID)
+	if err != nil {
+		return err
+	}
+	method := "GET"
+	var input io.Reader
+	if payload != nil {
+		b, err := currentCodec().Marshal(payload)
+		if err != nil {
+			return err
+		}
+		method, input = "POST", bytes.NewReader(b)
+	}
+	resp, err := d.callSystem(ctx, method, path, nil, input)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return responseError(resp, resp.Body)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	return currentCodec().Unmarshal(b, out)
+}
@@ -0,0 +1,50 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerFlow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /flows":
+			json.NewEncoder(w).Encode(map[string]any{"data": []any{
+				map[string]any{"id": "f1", "name": "Publish", "trigger": "webhook", "status": "active", "options": map[string]any{"method": "POST"}},
+			}})
+		case "POST /flows/trigger/f1":
+			var payload map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			json.NewEncoder(w).Encode(map[string]any{"published": payload["id"]})
+		case "GET /flows/trigger/f2":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]any{"errors": []any{map[string]any{"message": "You don't have permission to access this."}}})
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	flows, _, err := client.Flows().List(ctx, DirectusQuery{})
+	require.NoError(t, err)
+	require.Equal(t, "webhook", flows[0].Trigger)
+
+	var result struct {
+		Published float64 `json:"published"`
+	}
+	require.NoError(t, client.TriggerFlow(ctx, "f1", map[string]any{"id": 5}, &result))
+	require.Equal(t, float64(5), result.Published)
+
+	require.NoError(t, client.TriggerFlow(ctx, "f2", nil, &result))
+	require.ErrorIs(t, client.TriggerFlow(ctx, "f3", nil, nil), ErrForbidden)
+	require.Error(t, client.TriggerFlow(ctx, "", nil, nil))
+}
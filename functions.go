@@ -0,0 +1,50 @@
+package directus_client
+
+import "strings"
+
+// Date and time functions applied to fields in filters, fields, sort and
+// groupBy, e.g. Filter{Year("date_published"): {OP_eq: 2024}}.
+func Year(field string) string    { return "year(" + field + ")" }
+func Month(field string) string   { return "month(" + field + ")" }
+func Week(field string) string    { return "week(" + field + ")" }
+func Day(field string) string     { return "day(" + field + ")" }
+func Weekday(field string) string { return "weekday(" + field + ")" }
+func Hour(field string) string    { return "hour(" + field + ")" }
+func Minute(field string) string  { return "minute(" + field + ")" }
+func Second(field string) string  { return "second(" + field + ")" }
+
+// CountOf is the number of items related through the one-to-many or
+// many-to-many field, e.g. Fields{"id", CountOf("comments")}, which saves a
+// query per item for child counts. It can be filtered and sorted on too.
+func CountOf(field string) string { return "count(" + field + ")" }
+
+var fieldFunctions = map[string]bool{
+	"year": true, "month": true, "week": true, "day": true, "weekday": true,
+	"hour": true, "minute": true, "second": true, "count": true,
+}
+
+// ResultKey returns the key Directus returns a function field under,
+// "<field>_<function>", e.g. "comments_count" for CountOf("comments"). Other
+// fields are returned as they are.
+func ResultKey(field string) string {
+	prefix, segment := "", field
+	if i := strings.LastIndexByte(field, '.'); i >= 0 {
+		prefix, segment = field[:i+1], field[i+1:]
+	}
+	inner, ok := unwrapFunction(segment)
+	if !ok {
+		return field
+	}
+	return prefix + inner + "_" + segment[:strings.IndexByte(segment, '(')]
+}
+
+// unwrapFunction returns the field of a path segment wrapped in a function,
+// e.g. "date_published" for "year(date_published)", and whether it was.
+// Unknown functions are returned as they are, so they fail validation.
+func unwrapFunction(segment string) (string, bool) {
+	open := strings.IndexByte(segment, '(')
+	if open < 0 || !strings.HasSuffix(segment, ")") || !fieldFunctions[segment[:open]] {
+		return segment, false
+	}
+	return segment[open+1 : len(segment)-1], true
+}
@@ -0,0 +1,63 @@
+package directus_client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldFunctions(t *testing.T) {
+	q := DirectusQuery{
+		Fields:  Fields{"id", Year("date_published")},
+		Filter:  Filter{Year("date_published"): {OP_eq: float64(2024)}, "author." + Month("birthday"): {OP_eq: float64(6)}},
+		Sort:    Fields{"-" + Week("date_published")},
+		GroupBy: Fields{Weekday("date_published")},
+	}
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"year(date_published)":{"_eq":2024},"author":{"month(birthday)":{"_eq":6}}}`, v.Get("filter"))
+	require.Equal(t, "id,year(date_published)", v.Get("fields"))
+	require.Equal(t, "-week(date_published)", v.Get("sort"))
+
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, q.Filter, parsed.Filter)
+	require.Equal(t, q.Fields, parsed.Fields)
+	require.Equal(t, q.GroupBy, parsed.GroupBy)
+
+	for _, bad := range []string{"median(views)", "year(a b)", "year()", "year(date"} {
+		_, err := ParseQuery(url.Values{"filter": {"{}"}, "fields": {bad}})
+		require.Error(t, err, bad)
+	}
+	require.Equal(t, "hour(t)", Hour("t"))
+	require.Equal(t, "minute(t)", Minute("t"))
+	require.Equal(t, "second(t)", Second("t"))
+	require.Equal(t, "day(t)", Day("t"))
+}
+
+func TestCountOf(t *testing.T) {
+	q := DirectusQuery{
+		Fields: Fields{"id", CountOf("comments"), "author." + CountOf("books")},
+		Filter: Filter{CountOf("comments"): {OP_gt: float64(5)}},
+		Sort:   Fields{"-" + CountOf("comments")},
+	}
+	require.NoError(t, q.validate())
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "id,count(comments),author.count(books)", v.Get("fields"))
+	require.JSONEq(t, `{"count(comments)":{"_gt":5}}`, v.Get("filter"))
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, q.Fields, parsed.Fields)
+	require.Equal(t, q.Sort, parsed.Sort)
+
+	for _, bad := range []string{"count(*)", "count(year(date))", "count(a.b)", "count()"} {
+		q := DirectusQuery{Fields: Fields{bad}}
+		require.Error(t, q.validate(), bad)
+	}
+
+	require.Equal(t, "comments_count", ResultKey(CountOf("comments")))
+	require.Equal(t, "author.birthday_month", ResultKey("author."+Month("birthday")))
+	require.Equal(t, "title", ResultKey("title"))
+}
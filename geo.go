@@ -0,0 +1,63 @@
+package directus_client
+
+// Geometry is a GeoJSON geometry, the value of the geospatial filter
+// operators, e.g.
+//
+//	Filter{"location": {OP_intersects_bbox: BBox(13.0, 52.3, 13.8, 52.7)}}
+//
+// Coordinates are longitude first, as in GeoJSON.
+type Geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// Point is the GeoJSON point at lng, lat.
+func Point(lng float64, lat float64) Geometry {
+	return Geometry{Type: "Point", Coordinates: [2]float64{lng, lat}}
+}
+
+// LineString is the GeoJSON line through points, each a longitude and
+// latitude.
+func LineString(points ...[2]float64) Geometry {
+	return Geometry{Type: "LineString", Coordinates: points}
+}
+
+// Polygon is the GeoJSON polygon of an outer ring and optional holes. Rings
+// are closed by repeating their first point if they aren't already.
+func Polygon(outer [][2]float64, holes ...[][2]float64) Geometry {
+	rings := make([][][2]float64, 0, 1+len(holes))
+	for _, ring := range append([][][2]float64{outer}, holes...) {
+		if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+			ring = append(ring[:len(ring):len(ring)], ring[0])
+		}
+		rings = append(rings, ring)
+	}
+	return Geometry{Type: "Polygon", Coordinates: rings}
+}
+
+// BBox is the rectangle between two corners as a GeoJSON polygon.
+func BBox(minLng float64, minLat float64, maxLng float64, maxLat float64) Geometry {
+	return Polygon([][2]float64{{minLng, minLat}, {maxLng, minLat}, {maxLng, maxLat}, {minLng, maxLat}})
+}
+
+// validGeometry reports whether v looks like a GeoJSON geometry.
+func validGeometry(v any) bool {
+	switch v := v.(type) {
+	case Geometry:
+		return v.Type != "" && v.Coordinates != nil
+	case *Geometry:
+		return v != nil && v.Type != "" && v.Coordinates != nil
+	case map[string]any:
+		t, ok := v["type"].(string)
+		if !ok || t == "" {
+			return false
+		}
+		if t == "GeometryCollection" {
+			_, ok = v["geometries"].([]any)
+		} else {
+			_, ok = v["coordinates"].([]any)
+		}
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package directus_client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoFilter(t *testing.T) {
+	query := DirectusQuery{Filter: Or{
+		Filter{"area": {OP_intersects_bbox: BBox(13, 52.3, 13.8, 52.7)}},
+		Filter{"location": {OP_nintersects: Point(13.4, 52.5)}},
+		Filter{"route": {OP_intersects: LineString([2]float64{0, 0}, [2]float64{1, 1})}},
+	}}
+	require.NoError(t, query.validate())
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"_or":[
+		{"area":{"_intersects_bbox":{"type":"Polygon","coordinates":[[[13,52.3],[13.8,52.3],[13.8,52.7],[13,52.7],[13,52.3]]]}}},
+		{"location":{"_nintersects":{"type":"Point","coordinates":[13.4,52.5]}}},
+		{"route":{"_intersects":{"type":"LineString","coordinates":[[0,0],[1,1]]}}}
+	]}`, v.Get("filter"))
+
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"type": "Point", "coordinates": []any{13.4, 52.5}}, parsed.Filter.(Or)[1].(Filter)["location"][OP_nintersects])
+
+	// rings already closed stay as they are
+	ring := [][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 0}}
+	require.Equal(t, [][][2]float64{ring}, Polygon(ring).Coordinates)
+
+	for _, bad := range []any{"POINT(1 2)", Geometry{Type: "Point"}, map[string]any{"type": "Point"}} {
+		q := DirectusQuery{Filter: Filter{"location": {OP_intersects: bad}}}
+		require.EqualError(t, q.validate(), "filter location: _intersects takes a GeoJSON geometry")
+	}
+	_, err = ParseQuery(url.Values{"filter": {`{"location":{"_intersects":[1,2]}}`}})
+	require.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// getManyQueryBudget bounds the encoded keys of one GetMany request, keeping
+// URLs well below the 8 KiB many servers and proxies accept.
+const getManyQueryBudget = 4 << 10
+
+// GetMany returns the items with the given primary keys in the order of ids;
+// keys without an item, e.g. deleted or not permitted, are left out. The ids
+// are fetched with _in filters of up to ITEMS_MAX_LIMIT keys and
+// getManyQueryBudget bytes each, concurrency of them at a time.
+func (c *ItemsClient[T]) GetMany(ctx context.Context, ids []any, concurrency int) ([]T, error) {
+	keys := make([]string, len(ids))
+	var chunks [][]any
+	var chunk []any
+	size := 0
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		key, err := formatKey(id)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if len(chunk) == ITEMS_MAX_LIMIT || (len(chunk) > 0 && size+len(key) > getManyQueryBudget) {
+			chunks = append(chunks, chunk)
+			chunk, size = nil, 0
+		}
+		if s, ok := id.(fmt.Stringer); ok {
+			chunk = append(chunk, s.String())
+		} else {
+			chunk = append(chunk, id)
+		}
+		size += len(key) + 3
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	found, err := c.fetchChunks(ctx, chunks, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, 0, len(ids))
+	for _, key := range keys {
+		if item, ok := found[key]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// fetchChunks fetches the items of every chunk of keys, by primary key.
+func (c *ItemsClient[T]) fetchChunks(ctx context.Context, chunks [][]any, concurrency int) (map[string]T, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		found    = make(map[string]T)
+		firstErr error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(chunk []any) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			items, err := c.fetchChunk(ctx, chunk)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			for k, item := range items {
+				found[k] = item
+			}
+		}(chunk)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return found, nil
+}
+
+func (c *ItemsClient[T]) fetchChunk(ctx context.Context, keys []any) (map[string]T, error) {
+	resp, err := c.d.QueryContext(ctx, "GET", c.collection, DirectusQuery{
+		Filter: Filter{c.primaryKey: {OP_in: keys}},
+		Limit:  len(keys),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var rows []json.RawMessage
+	if err := readItems(resp, &rows, nil); err != nil {
+		return nil, err
+	}
+	items := make(map[string]T, len(rows))
+	for _, row := range rows {
+		var pk map[string]json.RawMessage
+		var item T
+		if err := currentCodec().Unmarshal(row, &pk); err != nil {
+			return nil, err
+		}
+		if err := currentCodec().Unmarshal(row, &item); err != nil {
+			return nil, err
+		}
+		raw, ok := pk[c.primaryKey]
+		if !ok {
+			return nil, fmt.Errorf("directus: no primary key %q in %s", c.primaryKey, c.collection)
+		}
+		items[rawKey(raw)] = item
+	}
+	return items, nil
+}
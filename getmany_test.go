@@ -0,0 +1,47 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestGetMany(t *testing.T) {
+	e := emulator.New("token")
+	for i := 0; i < 1500; i++ {
+		e.Seed("articles", map[string]any{"title": "a"})
+	}
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		require.Less(t, len(r.URL.RawQuery), 8<<10)
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+
+	var ids []any
+	for i := 1500; i > 0; i-- {
+		ids = append(ids, i)
+	}
+	ids = append(ids, 9999, 7, int64(1))
+	got, err := articles.GetMany(context.Background(), ids, 4)
+	require.NoError(t, err)
+	require.Len(t, got, 1502)
+	require.Equal(t, 1500, got[0].ID)
+	require.Equal(t, 1, got[1499].ID)
+	require.Equal(t, 7, got[1500].ID)
+	require.Equal(t, 1, got[1501].ID)
+	require.Greater(t, atomic.LoadInt32(&requests), int32(1))
+
+	got, err = articles.GetMany(context.Background(), nil, 1)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
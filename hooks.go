@@ -0,0 +1,81 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a Call for hooks. Status and Duration are only set
+// after the request completed, Err only for OnError.
+type RequestInfo struct {
+	RequestID  string
+	Method     string
+	Collection string
+	RawQuery   string
+	Status     int
+	Duration   time.Duration
+	Err        error
+}
+
+// Hooks are called around every Call, Query and Proxy request, outside the
+// middleware chain, so cache hits are reported as well. Any of them may be
+// nil. They run synchronously and must not block.
+type Hooks struct {
+	// OnRequest is called before the request is dispatched.
+	OnRequest func(ctx context.Context, info RequestInfo)
+	// OnResponse is called once a response, possibly an error status, was
+	// received.
+	OnResponse func(ctx context.Context, info RequestInfo)
+	// OnError is called when no response was received.
+	OnError func(ctx context.Context, info RequestInfo)
+}
+
+// WithHooks adds lifecycle hooks, e.g. for audit logging. Hooks added by
+// several calls run in order.
+func WithHooks(h Hooks) ClientOption {
+	return func(d *DirectusClient) {
+		d.hooks = append(d.hooks, h)
+	}
+}
+
+// dispatch runs next with the hooks of d around it.
+func (d *DirectusClient) dispatch(req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	if len(d.hooks) == 0 {
+		return next(req)
+	}
+	ctx := req.Context()
+	info := RequestInfo{
+		RequestID: RequestIDFromContext(ctx),
+		Method:    req.Method,
+		RawQuery:  req.URL.RawQuery,
+	}
+	if split := strings.SplitN(req.URL.Path, "items/", 2); len(split) == 2 {
+		info.Collection = split[1]
+	}
+	for _, h := range d.hooks {
+		if h.OnRequest != nil {
+			h.OnRequest(ctx, info)
+		}
+	}
+	start := time.Now()
+	resp, err := next(req)
+	info.Duration = time.Since(start)
+	if err != nil {
+		info.Err = err
+		for _, h := range d.hooks {
+			if h.OnError != nil {
+				h.OnError(ctx, info)
+			}
+		}
+		return nil, err
+	}
+	info.Status = resp.StatusCode
+	for _, h := range d.hooks {
+		if h.OnResponse != nil {
+			h.OnResponse(ctx, info)
+		}
+	}
+	return resp, nil
+}
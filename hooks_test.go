@@ -0,0 +1,60 @@
+package directus_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/items/missing" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+	}))
+	var events []string
+	record := func(event string) func(context.Context, RequestInfo) {
+		return func(ctx context.Context, info RequestInfo) {
+			require.Equal(t, RequestIDFromContext(ctx), info.RequestID)
+			events = append(events, fmt.Sprint(event, " ", info.Method, " ", info.Collection, " ", info.Status, " ", info.Err != nil))
+			if event != "request" {
+				require.Greater(t, int64(info.Duration), int64(0))
+			}
+		}
+	}
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithHooks(Hooks{
+		OnRequest:  record("request"),
+		OnResponse: record("response"),
+		OnError:    record("error"),
+	}), WithHooks(Hooks{OnResponse: record("audit")}))
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	resp, err := client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	resp, err = client.Query("GET", "missing", query, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	srv.Close()
+	_, err = client.Query("DELETE", "user/1", query, nil)
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		"request GET user 0 false",
+		"response GET user 200 false",
+		"audit GET user 200 false",
+		"request GET missing 0 false",
+		"response GET missing 403 false",
+		"audit GET missing 403 false",
+		"request DELETE user/1 0 false",
+		"error DELETE user/1 0 true",
+	}, events)
+}
@@ -0,0 +1,64 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Default headers carrying impersonation, see WithImpersonationHeaders.
+const (
+	ImpersonateUserHeader = "X-Directus-Impersonate-User"
+	ImpersonateRoleHeader = "X-Directus-Impersonate-Role"
+)
+
+// Impersonation names the user and/or role, by id, a request acts on behalf
+// of.
+type Impersonation struct {
+	User string
+	Role string
+}
+
+type impersonationKey struct{}
+
+// ContextWithImpersonation makes requests sent with ctx act on behalf of
+// imp, e.g. so a background job attributes its activity to the user that
+// triggered it. Directus itself has no impersonation; the instance needs an
+// extension that honors the headers and checks the token is allowed to
+// impersonate. Such requests bypass the query cache and deduplication.
+func ContextWithImpersonation(ctx context.Context, imp Impersonation) context.Context {
+	return context.WithValue(ctx, impersonationKey{}, imp)
+}
+
+func impersonationFromContext(ctx context.Context) (Impersonation, bool) {
+	imp, ok := ctx.Value(impersonationKey{}).(Impersonation)
+	return imp, ok
+}
+
+// WithImpersonationHeaders changes the headers impersonation is sent in from
+// ImpersonateUserHeader and ImpersonateRoleHeader to those expected by the
+// extension of the instance.
+func WithImpersonationHeaders(user string, role string) ClientOption {
+	return func(d *DirectusClient) {
+		d.impersonateUserHeader = user
+		d.impersonateRoleHeader = role
+	}
+}
+
+// setImpersonation sets the impersonation headers of req from its context.
+func (d *DirectusClient) setImpersonation(req *http.Request) bool {
+	imp, ok := impersonationFromContext(req.Context())
+	if !ok {
+		return false
+	}
+	userHeader, roleHeader := ImpersonateUserHeader, ImpersonateRoleHeader
+	if d.impersonateUserHeader != "" {
+		userHeader, roleHeader = d.impersonateUserHeader, d.impersonateRoleHeader
+	}
+	if imp.User != "" {
+		req.Header.Set(userHeader, imp.User)
+	}
+	if imp.Role != "" && roleHeader != "" {
+		req.Header.Set(roleHeader, imp.Role)
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonation(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Act-As")+"|"+r.Header.Get("X-Act-As-Role"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	store := &memoryCacheService{m: make(map[string][]byte)}
+	cache, err := NewRefreshableQueryCache(store, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache, WithImpersonationHeaders("X-Act-As", "X-Act-As-Role"))
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	ctx := ContextWithImpersonation(context.Background(), Impersonation{User: "u1", Role: "r1"})
+	for i := 0; i < 2; i++ {
+		resp, err := client.QueryContext(ctx, "GET", "user", query, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.Empty(t, store.m)
+	_, err = client.Health(ContextWithImpersonation(context.Background(), Impersonation{User: "u2"}))
+	require.Error(t, err)
+	resp, err := client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, []string{"u1|r1", "u1|r1", "u2|", "|"}, got)
+}
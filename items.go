@@ -0,0 +1,394 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Meta is the metadata of a list response, set when requested with
+// DirectusQuery.Meta.
+type Meta struct {
+	TotalCount  int `json:"total_count"`
+	FilterCount int `json:"filter_count"`
+}
+
+// ItemsClient reads and writes the items of one collection as T, sparing
+// callers the decoding of responses through ReadResult. Requests go through
+// the client it was created from, cache and middleware included.
+type ItemsClient[T any] struct {
+	d          *DirectusClient
+	collection string
+	primaryKey string
+}
+
+// Items returns a client for the items of collection, e.g.
+// Items[Article](client, "articles").
+func Items[T any](d *DirectusClient, collection string) *ItemsClient[T] {
+	return &ItemsClient[T]{d: d, collection: collection, primaryKey: "id"}
+}
+
+// WithPrimaryKey returns a copy of c for a collection whose primary key field
+// is not "id".
+func (c *ItemsClient[T]) WithPrimaryKey(field string) *ItemsClient[T] {
+	cc := *c
+	cc.primaryKey = field
+	return &cc
+}
+
+// Collection returns the collection of c.
+func (c *ItemsClient[T]) Collection() string {
+	return c.collection
+}
+
+// List returns the items matching query. Meta is zero unless query.Meta is
+// set.
+func (c *ItemsClient[T]) List(ctx context.Context, query DirectusQuery) ([]T, Meta, error) {
+	resp, err := c.d.QueryContext(ctx, "GET", c.collection, query, nil)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	var items []T
+	var meta Meta
+	if err := readItems(resp, &items, &meta); err != nil {
+		return nil, Meta{}, err
+	}
+	return items, meta, nil
+}
+
+// Get returns the item with primary key id, an integer, a string or a
+// fmt.Stringer such as uuid.UUID. Items are cached per collection and id.
+func (c *ItemsClient[T]) Get(ctx context.Context, id any) (T, error) {
+	var item T
+	path, err := c.itemPath(id)
+	if err != nil {
+		return item, err
+	}
+	err = c.send(ctx, "GET", path, nil, nil, &item)
+	return item, err
+}
+
+// Create creates item and returns it as stored by Directus, reduced to fields
+// if given, e.g. "id" or "*.*" for relations.
+func (c *ItemsClient[T]) Create(ctx context.Context, item T, fields ...string) (T, error) {
+	var created T
+	err := c.send(ctx, "POST", c.collection, fieldsQuery(fields), item, &created)
+	return created, err
+}
+
+// Update patches the item with primary key id and returns it as stored by
+// Directus, reduced to fields if given. patch is typically a map or a struct
+// with omitempty fields, as every field it encodes is written.
+func (c *ItemsClient[T]) Update(ctx context.Context, id any, patch any, fields ...string) (T, error) {
+	var updated T
+	path, err := c.itemPath(id)
+	if err != nil {
+		return updated, err
+	}
+	err = c.send(ctx, "PATCH", path, fieldsQuery(fields), patch, &updated)
+	return updated, err
+}
+
+// UpdateMany applies patch to the items with the given primary keys in one
+// request and returns the updated items.
+func (c *ItemsClient[T]) UpdateMany(ctx context.Context, keys []any, patch any) ([]T, error) {
+	var updated []T
+	err := c.send(ctx, "PATCH", c.collection, nil, map[string]any{"keys": keys, "data": patch}, &updated)
+	return updated, err
+}
+
+// UpdateBatch updates several items in one request, each with its own
+// values. Every item must include its primary key; all fields it encodes are
+// written.
+func (c *ItemsClient[T]) UpdateBatch(ctx context.Context, items []T) ([]T, error) {
+	var updated []T
+	err := c.send(ctx, "PATCH", c.collection, nil, items, &updated)
+	return updated, err
+}
+
+// Delete deletes the item with primary key id.
+func (c *ItemsClient[T]) Delete(ctx context.Context, id any) error {
+	path, err := c.itemPath(id)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "DELETE", path, nil, nil, nil)
+}
+
+// upsertAttempts bounds the lookups of Upsert racing concurrent creates.
+const upsertAttempts = 3
+
+// Upsert updates the item matching uniqueFilter with item, or creates item if
+// there is none, and returns the item as stored by Directus. Directus has no
+// native upsert, so this is a lookup followed by a PATCH or POST; if the POST
+// fails with RECORD_NOT_UNIQUE, because a concurrent Upsert created the item
+// first, the lookup is repeated. Other errors of the POST are returned as is.
+// The lookup bypasses the cache and fails if more than one item matches. item
+// should omit its primary key when zero, e.g. by omitempty.
+func (c *ItemsClient[T]) Upsert(ctx context.Context, uniqueFilter FilterNode, item T) (T, error) {
+	var zero T
+	if filterEmpty(uniqueFilter) {
+		return zero, errors.New("directus: upsert requires a filter")
+	}
+	var err error
+	for attempt := 0; attempt < upsertAttempts; attempt++ {
+		var key string
+		var found bool
+		if key, found, err = c.lookupKey(ctx, uniqueFilter); err != nil {
+			return zero, err
+		}
+		if found {
+			return c.Update(ctx, key, item)
+		}
+		var created T
+		if created, err = c.Create(ctx, item); err == nil || !isNotUnique(err) {
+			return created, err
+		}
+	}
+	return zero, err
+}
+
+// isNotUnique reports whether err is a Directus unique constraint violation.
+func isNotUnique(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, de := range apiErr.Errors {
+		if de.Extensions.Code == "RECORD_NOT_UNIQUE" {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupKey returns the primary key of the only item matching filter.
+func (c *ItemsClient[T]) lookupKey(ctx context.Context, filter FilterNode) (string, bool, error) {
+	resp, err := c.d.QueryContext(withoutCache(ctx), "GET", c.collection, DirectusQuery{
+		Fields: Fields{c.primaryKey},
+		Filter: filter,
+		Limit:  2,
+	}, nil)
+	if err != nil {
+		return "", false, err
+	}
+	var items []map[string]json.RawMessage
+	if err := readItems(resp, &items, nil); err != nil {
+		return "", false, err
+	}
+	switch len(items) {
+	case 0:
+		return "", false, nil
+	case 1:
+	default:
+		return "", false, fmt.Errorf("directus: upsert filter matches more than one item of %s", c.collection)
+	}
+	raw, ok := items[0][c.primaryKey]
+	if !ok {
+		return "", false, fmt.Errorf("directus: no primary key %q in %s", c.primaryKey, c.collection)
+	}
+	return rawKey(raw), true, nil
+}
+
+// rawKey formats a primary key as found in a response like formatKey.
+func rawKey(raw json.RawMessage) string {
+	var key string
+	if err := json.Unmarshal(raw, &key); err != nil {
+		// numeric keys
+		return string(raw)
+	}
+	return key
+}
+
+// ErrUnfilteredDelete is returned by DeleteWhere for an empty filter, which
+// would delete every item of the collection.
+var ErrUnfilteredDelete = errors.New("directus: refusing to delete without a filter")
+
+// DeleteOption configures DeleteWhere.
+type DeleteOption func(*deleteConfig)
+
+type deleteConfig struct {
+	allowUnfiltered bool
+}
+
+// AllowUnfilteredDelete lets DeleteWhere delete with an empty filter, i.e.
+// truncate the collection.
+func AllowUnfilteredDelete() DeleteOption {
+	return func(c *deleteConfig) {
+		c.allowUnfiltered = true
+	}
+}
+
+// DeleteMany deletes the items with the given primary keys in one request.
+func (c *ItemsClient[T]) DeleteMany(ctx context.Context, keys []any) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.send(ctx, "DELETE", c.collection, nil, keys, nil)
+}
+
+// DeleteWhere deletes the items matching filter in one request. It fails
+// with ErrUnfilteredDelete if filter has no conditions, unless
+// AllowUnfilteredDelete is given.
+func (c *ItemsClient[T]) DeleteWhere(ctx context.Context, filter FilterNode, opts ...DeleteOption) error {
+	var cfg deleteConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if filterEmpty(filter) && !cfg.allowUnfiltered {
+		return ErrUnfilteredDelete
+	}
+	if filterEmpty(filter) {
+		filter = Filter{}
+	}
+	return c.send(ctx, "DELETE", c.collection, nil, map[string]any{
+		"query": map[string]any{"filter": filterJSON(filter)},
+	}, nil)
+}
+
+func (c *ItemsClient[T]) itemPath(id any) (string, error) {
+	key, err := formatKey(id)
+	if err != nil {
+		return "", err
+	}
+	return c.collection + "/" + key, nil
+}
+
+// formatKey formats a primary key for an item path.
+func formatKey(id any) (string, error) {
+	var key string
+	switch v := id.(type) {
+	case int:
+		key = strconv.Itoa(v)
+	case int8, int16, int32, int64:
+		key = strconv.FormatInt(reflect.ValueOf(v).Int(), 10)
+	case uint, uint8, uint16, uint32, uint64:
+		key = strconv.FormatUint(reflect.ValueOf(v).Uint(), 10)
+	case string:
+		key = v
+	case fmt.Stringer:
+		key = v.String()
+	default:
+		return "", fmt.Errorf("directus: unsupported primary key type %T", id)
+	}
+	if key == "" || strings.Contains(key, "/") {
+		return "", fmt.Errorf("directus: invalid primary key %q", key)
+	}
+	return key, nil
+}
+
+// fieldsQuery selects the fields returned by a mutation, all if empty.
+func fieldsQuery(fields []string) url.Values {
+	if len(fields) == 0 {
+		return nil
+	}
+	return url.Values{"fields": {strings.Join(fields, ",")}}
+}
+
+// send sends body, if not nil, as JSON to /items/path and decodes the data of
+// the response into out, if not nil.
+func (c *ItemsClient[T]) send(ctx context.Context, method string, path string, query url.Values, body any, out any) error {
+	var req *http.Request
+	if body != nil {
+		b, err := currentCodec().Marshal(body)
+		if err != nil {
+			return err
+		}
+		req = c.d.newItemsRequest(ctx, method, path, query, bytes.NewReader(b))
+	} else {
+		req = c.d.newItemsRequest(ctx, method, path, query, nil)
+	}
+	resp, err := c.d.CallContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	return readItems(resp, out, nil)
+}
+
+// readItems decodes the data and meta of an /items response into data and
+// meta, either may be nil, and closes its body. Unlike readData it honors
+// the content type and encoding, as /items responses may be served from the
+// cache as stored.
+func readItems(resp *http.Response, data any, meta *Meta) error {
+	defer resp.Body.Close()
+	body, err := responseBody(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return responseError(resp, body)
+	}
+	if resp.StatusCode == http.StatusNoContent || (data == nil && meta == nil) {
+		return nil
+	}
+	return decoderFor(resp.Header.Get("Content-Type"))(body, &struct {
+		Data any   `json:"data"`
+		Meta *Meta `json:"meta,omitempty"`
+	}{data, meta})
+}
+
+// BatchError is returned by CreateMany when some batches failed. Directus
+// creates the items of a batch in one transaction, so a failed batch created
+// none of its items.
+type BatchError struct {
+	Failures []BatchFailure
+}
+
+// BatchFailure is a failed batch of items[Offset:Offset+Len].
+type BatchFailure struct {
+	Offset int
+	Len    int
+	Err    error
+}
+
+func (e *BatchError) Error() string {
+	f := e.Failures[0]
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("directus: batch of items %d-%d failed: %v", f.Offset, f.Offset+f.Len-1, f.Err)
+	}
+	return fmt.Sprintf("directus: %d batches failed, first of items %d-%d: %v", len(e.Failures), f.Offset, f.Offset+f.Len-1, f.Err)
+}
+
+// Unwrap returns the error of the first failed batch.
+func (e *BatchError) Unwrap() error {
+	return e.Failures[0].Err
+}
+
+// CreateMany creates items with one request per batchSize items, all of them
+// in one request if batchSize is 0, and returns the created items in order.
+// Failed batches don't stop the others; they are reported by a *BatchError
+// returned together with the items that were created.
+func (c *ItemsClient[T]) CreateMany(ctx context.Context, items []T, batchSize int) ([]T, error) {
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+	created := make([]T, 0, len(items))
+	var failures []BatchFailure
+	for offset := 0; offset < len(items); offset += batchSize {
+		end := offset + batchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		var batch []T
+		err := c.send(ctx, "POST", c.collection, nil, items[offset:end], &batch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return created, err
+			}
+			failures = append(failures, BatchFailure{Offset: offset, Len: end - offset, Err: err})
+			continue
+		}
+		created = append(created, batch...)
+	}
+	if len(failures) > 0 {
+		return created, &BatchError{Failures: failures}
+	}
+	return created, nil
+}
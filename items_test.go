@@ -0,0 +1,294 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+type article struct {
+	ID     int    `json:"id,omitempty"`
+	Title  string `json:"title"`
+	Status string `json:"status,omitempty"`
+}
+
+func newItemsClient(t *testing.T) (*DirectusClient, *emulator.Emulator) {
+	e := emulator.New("token")
+	e.Seed("articles",
+		map[string]any{"title": "Hello", "status": "published"},
+		map[string]any{"title": "Draft", "status": "draft"},
+	)
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	return client, e
+}
+
+func TestItems(t *testing.T) {
+	client, _ := newItemsClient(t)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	meta := MetaQueryAll
+	list, m, err := articles.List(ctx, DirectusQuery{
+		Filter: Filter{"status": {OP_eq: "published"}},
+		Meta:   &meta,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []article{{ID: 1, Title: "Hello", Status: "published"}}, list)
+	require.Equal(t, Meta{TotalCount: 2, FilterCount: 1}, m)
+
+	created, err := articles.Create(ctx, article{Title: "New"})
+	require.NoError(t, err)
+	require.Equal(t, article{ID: 3, Title: "New"}, created)
+
+	updated, err := articles.Update(ctx, "3", map[string]any{"status": "draft"})
+	require.NoError(t, err)
+	require.Equal(t, article{ID: 3, Title: "New", Status: "draft"}, updated)
+
+	got, err := articles.Get(ctx, "3")
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+
+	require.NoError(t, articles.Delete(ctx, "3"))
+	_, err = articles.Get(ctx, "3")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "403 Forbidden")
+}
+
+func TestCreateMany(t *testing.T) {
+	e := emulator.New("token")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte(`"title":"bad"`)) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"Validation failed for field \"title\"."}]}`))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	created, err := articles.CreateMany(ctx, []article{{Title: "a"}, {Title: "b"}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []article{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}}, created)
+
+	created, err = articles.CreateMany(ctx, []article{{Title: "c"}, {Title: "bad"}, {Title: "d"}, {Title: "e"}, {Title: "bad"}}, 2)
+	require.Equal(t, []article{{ID: 3, Title: "d"}, {ID: 4, Title: "e"}}, created)
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	require.Len(t, batchErr.Failures, 2)
+	require.Equal(t, 0, batchErr.Failures[0].Offset)
+	require.Equal(t, 2, batchErr.Failures[0].Len)
+	require.Equal(t, 4, batchErr.Failures[1].Offset)
+	require.Equal(t, 1, batchErr.Failures[1].Len)
+	require.Contains(t, err.Error(), "Validation failed")
+}
+
+func TestUpdateMany(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PATCH", r.Method)
+		require.Equal(t, "/items/articles", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"title":"a","status":"draft"},{"id":2,"title":"b","status":"draft"}]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	updated, err := articles.UpdateMany(ctx, []any{1, 2}, map[string]any{"status": "draft"})
+	require.NoError(t, err)
+	require.Len(t, updated, 2)
+	require.Equal(t, "draft", updated[1].Status)
+
+	_, err = articles.UpdateBatch(ctx, []article{{ID: 1, Title: "a", Status: "draft"}, {ID: 2, Title: "b", Status: "draft"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`{"data":{"status":"draft"},"keys":[1,2]}`,
+		`[{"id":1,"title":"a","status":"draft"},{"id":2,"title":"b","status":"draft"}]`,
+	}, bodies)
+}
+
+func TestDeleteMany(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "DELETE", r.Method)
+		require.Equal(t, "/items/articles", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	require.NoError(t, articles.DeleteMany(ctx, []any{1, 2}))
+	require.NoError(t, articles.DeleteMany(ctx, nil))
+	require.NoError(t, articles.DeleteWhere(ctx, Filter{"status": {OP_eq: "draft"}}))
+	require.ErrorIs(t, articles.DeleteWhere(ctx, nil), ErrUnfilteredDelete)
+	require.ErrorIs(t, articles.DeleteWhere(ctx, Filter{"status": {}}), ErrUnfilteredDelete)
+	require.NoError(t, articles.DeleteWhere(ctx, nil, AllowUnfilteredDelete()))
+	require.Equal(t, []string{
+		`[1,2]`,
+		`{"query":{"filter":{"status":{"_eq":"draft"}}}}`,
+		`{"query":{"filter":{}}}`,
+	}, bodies)
+}
+
+func TestUpsert(t *testing.T) {
+	e := emulator.New("token")
+	e.Seed("articles", map[string]any{"title": "Hello", "status": "draft"})
+	var raced, invalid bool
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts++
+		}
+		if r.Method == "POST" && !raced {
+			// a concurrent upsert wins the race
+			raced = true
+			e.Seed("articles", map[string]any{"title": "Race", "status": "draft"})
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"Value has to be unique.","extensions":{"code":"RECORD_NOT_UNIQUE"}}]}`))
+			return
+		}
+		if r.Method == "POST" && invalid {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"Validation failed.","extensions":{"code":"FAILED_VALIDATION"}}]}`))
+			return
+		}
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	// warm the cache with the lookup of the next upsert
+	_, err = client.Query("GET", "articles", DirectusQuery{Fields: Fields{"id"}, Filter: Filter{"title": {OP_eq: "Race"}}, Limit: 2}, nil)
+	require.NoError(t, err)
+
+	got, err := articles.Upsert(ctx, Filter{"title": {OP_eq: "Hello"}}, article{Title: "Hello", Status: "published"})
+	require.NoError(t, err)
+	require.Equal(t, article{ID: 1, Title: "Hello", Status: "published"}, got)
+
+	got, err = articles.Upsert(ctx, Filter{"title": {OP_eq: "Race"}}, article{Title: "Race", Status: "published"})
+	require.NoError(t, err)
+	require.True(t, raced)
+	require.Equal(t, article{ID: 2, Title: "Race", Status: "published"}, got)
+
+	got, err = articles.Upsert(ctx, Filter{"title": {OP_eq: "New"}}, article{Title: "New"})
+	require.NoError(t, err)
+	require.Equal(t, article{ID: 3, Title: "New"}, got)
+
+	// other errors of the create are not retried
+	invalid, posts = true, 0
+	_, err = articles.Upsert(ctx, Filter{"title": {OP_eq: "Invalid"}}, article{Title: "Invalid"})
+	require.ErrorIs(t, err, ErrValidation)
+	require.Equal(t, 1, posts)
+	invalid = false
+
+	_, err = articles.Upsert(ctx, Filter{"status": {OP_eq: "published"}}, article{Title: "Any"})
+	require.Error(t, err)
+	_, err = articles.Upsert(ctx, nil, article{Title: "Any"})
+	require.Error(t, err)
+}
+
+type uuidKey [2]byte
+
+func (k uuidKey) String() string {
+	return fmt.Sprintf("%x-%x", k[0], k[1])
+}
+
+func TestGetByKey(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":1,"title":"Hello"}}`))
+	}))
+	defer srv.Close()
+	wes := &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))}
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, wes)
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	for _, id := range []any{1, int64(1), uint8(2), "a b", uuidKey{0xab, 0xcd}, 1} {
+		got, err := articles.Get(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, article{ID: 1, Title: "Hello"}, got)
+	}
+	// the second 1 is served from the cache
+	require.Equal(t, []string{"/items/articles/1", "/items/articles/2", "/items/articles/a%20b", "/items/articles/ab-cd"}, paths)
+	// and pruned with the collection
+	require.Contains(t, wes.observes, "articles")
+	require.NotContains(t, wes.observes, "articles/1")
+	wes.observes["articles"](ctx, WebhookEvent{Event: "items.update", Collection: "articles", Key: "1"})
+	_, err = articles.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "/items/articles/1", paths[len(paths)-1])
+	require.Len(t, paths, 5)
+
+	_, err = articles.Get(ctx, 1.5)
+	require.Error(t, err)
+	_, err = articles.Get(ctx, "")
+	require.Error(t, err)
+	_, err = articles.Get(ctx, "a/b")
+	require.Error(t, err)
+}
+
+func TestMutationFields(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.Method+" "+r.URL.Query().Get("fields"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":3}}`))
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		created, err := articles.Create(ctx, article{Title: "New"}, "id")
+		require.NoError(t, err)
+		require.Equal(t, article{ID: 3}, created)
+	}
+	_, err = articles.Update(ctx, 3, map[string]any{"title": "Old"}, "id", "title")
+	require.NoError(t, err)
+	_, err = articles.Update(ctx, 3, map[string]any{"title": "Old"})
+	require.NoError(t, err)
+	// mutations are neither served from nor stored in the cache
+	require.Equal(t, []string{"POST id", "POST id", "PATCH id,title", "PATCH "}, queries)
+}
@@ -0,0 +1,124 @@
+package directus_client
+
+import (
+	"context"
+	"strings"
+)
+
+// Iterator walks the items matching a query page by page, fetching the next
+// page once the current one is consumed:
+//
+//	it := articles.Iterate(ctx, query)
+//	for it.Next() {
+//		process(it.Item())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Pages are as large as the limit of the query, ITEMS_MAX_LIMIT if unset or
+// ITEMS_NO_LIMIT.
+// Items created or deleted while iterating shift the pages, so sort by a
+// stable key, e.g. the primary key, and expect items to be skipped or
+// repeated on collections that change meanwhile.
+type Iterator[T any] struct {
+	c     *ItemsClient[T]
+	ctx   context.Context
+	query DirectusQuery
+	// next is the offset or page of the next request
+	next int
+
+	page []T
+	i    int
+	meta Meta
+	done bool
+	err  error
+}
+
+// Iterate returns an iterator over the items matching query. It pages with
+// offset, starting at the offset of query, with page numbers if query has a
+// page set, or with the cursor of query, moved to the last item of each page,
+// if it has one. Prefer cursors for large collections, see PageByCursor.
+func (c *ItemsClient[T]) Iterate(ctx context.Context, query DirectusQuery) *Iterator[T] {
+	if query.Limit <= 0 {
+		query.Limit = ITEMS_MAX_LIMIT
+	}
+	// pages shortened by the policy must not end the iteration
+	if p := c.d.policy; p != nil && p.ClampLimit && p.MaxLimit > 0 && query.Limit > p.MaxLimit {
+		query.Limit = p.MaxLimit
+	}
+	it := &Iterator[T]{c: c, ctx: ctx, query: query, next: query.Offset, i: -1}
+	if query.cursor != nil {
+		return it
+	}
+	if query.pageIsSet {
+		it.next = query.Page
+		if it.next < 1 {
+			it.next = 1
+		}
+	} else {
+		it.query.offsetIsSet = true
+	}
+	return it
+}
+
+// Next advances to the next item, fetching the next page if needed. It
+// returns false when there are no more items or a request failed.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.i+1 < len(it.page) {
+		it.i++
+		return true
+	}
+	if it.done {
+		return false
+	}
+	switch c := it.query.cursor; {
+	case c != nil:
+		if len(it.page) > 0 {
+			after, err := cursorValue(it.page[len(it.page)-1], strings.TrimPrefix(c.Field, "-"))
+			if err != nil {
+				it.err = err
+				return false
+			}
+			it.query.Paginate(CursorPagination(c.Field, after))
+		}
+	case it.query.pageIsSet:
+		it.query.Page = it.next
+		it.next++
+	default:
+		it.query.Offset = it.next
+		it.next += it.query.Limit
+	}
+	page, meta, err := it.c.List(it.ctx, it.query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if it.query.Meta != nil {
+		// counts don't change between pages, only fetch them once
+		it.meta = meta
+		it.query.Meta = nil
+	}
+	it.page, it.i = page, 0
+	it.done = len(page) < it.query.Limit
+	return len(page) > 0
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.page[it.i]
+}
+
+// Meta returns the counts requested by the Meta of the query, known after
+// the first call of Next.
+func (it *Iterator[T]) Meta() Meta {
+	return it.meta
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,61 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestIterate(t *testing.T) {
+	e := emulator.New("token")
+	for i := 0; i < 25; i++ {
+		e.Seed("articles", map[string]any{"title": "a"})
+	}
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	meta := MetaQueryTotalCount
+	it := articles.Iterate(ctx, DirectusQuery{Filter: Filter{"id": {OP_nnull: true}}, Sort: Fields{"id"}, Limit: 10, Meta: &meta})
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, ids, 25)
+	require.Equal(t, 25, ids[24])
+	require.Equal(t, 25, it.Meta().TotalCount)
+	require.Len(t, queries, 3)
+	require.Equal(t, "20", queries[2].Get("offset"))
+	require.Equal(t, "total_count", queries[0].Get("meta"))
+	require.Empty(t, queries[1].Get("meta"))
+
+	queries = nil
+	query, err := ParseQuery(url.Values{"page": {"2"}, "limit": {"10"}, "sort": {"id"}, "filter": {`{"id":{"_nnull":true}}`}})
+	require.NoError(t, err)
+	it = articles.Iterate(ctx, *query)
+	ids = nil
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 11, ids[0])
+	require.Len(t, ids, 15)
+	require.Equal(t, "3", queries[1].Get("page"))
+
+	it = Items[article](client, "missing").Iterate(ctx, DirectusQuery{Filter: Filter{"id": {OP_nnull: true}}})
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), ErrForbidden)
+}
@@ -0,0 +1,74 @@
+package directus_client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseTooLargeError is returned when a response body exceeds the limit
+// set with WithMaxResponseSize, either by Call when Directus announced the
+// size up front or while the body is read.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("directus: response body exceeds %d bytes", e.Limit)
+}
+
+// WithMaxResponseSize makes reading a response body, decompressed, of more
+// than n bytes fail with a *ResponseTooLargeError instead of buffering it, so
+// neither the cache, deduplication nor ReadResult hold unbounded payloads in
+// memory. It applies to QueryTo exports too. There is no limit by default.
+func WithMaxResponseSize(n int64) ClientOption {
+	return func(d *DirectusClient) {
+		d.maxResponseSize = n
+	}
+}
+
+// limitBody applies the response size limit of d to resp.
+func (d *DirectusClient) limitBody(resp *http.Response) error {
+	if d.maxResponseSize <= 0 {
+		return nil
+	}
+	if resp.ContentLength > d.maxResponseSize {
+		resp.Body.Close()
+		return &ResponseTooLargeError{Limit: d.maxResponseSize}
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, limit: d.maxResponseSize}
+	return nil
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.read > b.limit {
+		return 0, &ResponseTooLargeError{Limit: b.limit}
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), &ResponseTooLargeError{Limit: b.limit}
+	}
+	return n, err
+}
+
+// WithMaxLimit sets the most items per request the Directus server allows,
+// its QUERY_LIMIT_MAX, ITEMS_MAX_LIMIT by default. Queries with a larger
+// limit fail before they are sent. ITEMS_NO_LIMIT lifts the bound and allows
+// queries for all items with a limit of -1.
+func WithMaxLimit(n int) ClientOption {
+	return func(d *DirectusClient) {
+		if n < 1 && n != ITEMS_NO_LIMIT {
+			d.optErr = errors.New("max limit must be positive or ITEMS_NO_LIMIT")
+			return
+		}
+		d.maxLimit = n
+	}
+}
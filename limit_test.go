@@ -0,0 +1,97 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	body := `{"data":[{"id":1,"email":"` + strings.Repeat("x", 1024) + `@dev.io"}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/items/sized" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		} else {
+			w.(http.Flusher).Flush()
+		}
+		io.WriteString(w, body)
+	}))
+	defer srv.Close()
+	store := &memoryCacheService{m: make(map[string][]byte)}
+	cache, err := NewRefreshableQueryCache(store, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache, WithMaxResponseSize(512))
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	_, err = client.Query("GET", "sized", query, nil)
+	var tooLarge *ResponseTooLargeError
+	require.True(t, errors.As(err, &tooLarge), err)
+	require.EqualValues(t, 512, tooLarge.Limit)
+
+	// without Content-Length the limit hits while reading
+	resp, err := client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	result := ReadResult[[]user](resp)
+	require.True(t, result.Err())
+	require.Contains(t, result.Errors[0].Message, "exceeds 512 bytes")
+	resp, err = client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	n, err := io.Copy(io.Discard, resp.Body)
+	require.True(t, errors.As(err, &tooLarge), err)
+	require.EqualValues(t, 512, n)
+	require.Empty(t, store.m)
+}
+
+func TestMaxLimit(t *testing.T) {
+	for limit, wantErr := range map[int]string{
+		0:               "",
+		ITEMS_MAX_LIMIT: "",
+		1001:            "limit must be at most 1000",
+		ITEMS_NO_LIMIT:  "limit -1 is not allowed, at most 1000 items can be requested",
+		-2:              "limit must be -1 for all items or positive",
+	} {
+		// a filter is no longer required
+		q := DirectusQuery{Limit: limit}
+		if wantErr == "" {
+			require.NoError(t, q.validate(), limit)
+		} else {
+			require.EqualError(t, q.validate(), wantErr, limit)
+		}
+	}
+
+	e := emulator.New("token")
+	for i := 0; i < 1200; i++ {
+		e.Seed("articles", map[string]any{"title": strconv.Itoa(i)})
+	}
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	_, _, err = Items[article](client, "articles").List(context.Background(), DirectusQuery{Limit: ITEMS_NO_LIMIT})
+	require.EqualError(t, err, "limit -1 is not allowed, at most 1000 items can be requested")
+
+	client, err = NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithMaxLimit(ITEMS_NO_LIMIT))
+	require.NoError(t, err)
+	list, _, err := Items[article](client, "articles").List(context.Background(), DirectusQuery{Limit: ITEMS_NO_LIMIT})
+	require.NoError(t, err)
+	require.Len(t, list, 1200)
+
+	client, err = NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithMaxLimit(100))
+	require.NoError(t, err)
+	_, _, err = Items[article](client, "articles").List(context.Background(), DirectusQuery{Limit: 200})
+	require.EqualError(t, err, "limit must be at most 100")
+
+	_, err = NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithMaxLimit(0))
+	require.Error(t, err)
+}
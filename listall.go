@@ -0,0 +1,85 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrListTooLarge is returned by ListAll when a guard stopped it.
+var ErrListTooLarge = errors.New("directus: list exceeds limit")
+
+// DefaultListAllMaxItems is the item guard of ListAll without MaxItems.
+const DefaultListAllMaxItems = 100_000
+
+// ListAllOption configures the guards of ListAll.
+type ListAllOption func(*listAllConfig)
+
+type listAllConfig struct {
+	maxPages    int
+	maxItems    int
+	maxDuration time.Duration
+}
+
+// MaxPages fails ListAll once it would fetch more than n pages.
+func MaxPages(n int) ListAllOption {
+	return func(c *listAllConfig) {
+		c.maxPages = n
+	}
+}
+
+// MaxItems fails ListAll once more than n items match, instead of
+// DefaultListAllMaxItems; 0 disables the guard.
+func MaxItems(n int) ListAllOption {
+	return func(c *listAllConfig) {
+		c.maxItems = n
+	}
+}
+
+// MaxDuration fails ListAll if fetching all pages takes longer than d.
+func MaxDuration(d time.Duration) ListAllOption {
+	return func(c *listAllConfig) {
+		c.maxDuration = d
+	}
+}
+
+// ListAll returns all items matching query, paging like Iterate. So a bad
+// filter can't pull a whole collection into memory, it fails with
+// ErrListTooLarge after DefaultListAllMaxItems items, or as configured with
+// MaxItems, MaxPages and MaxDuration.
+func (c *ItemsClient[T]) ListAll(ctx context.Context, query DirectusQuery, opts ...ListAllOption) ([]T, error) {
+	cfg := listAllConfig{maxItems: DefaultListAllMaxItems}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	parent := ctx
+	if cfg.maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.maxDuration)
+		defer cancel()
+	}
+	var items []T
+	pages := 0
+	it := c.Iterate(ctx, query)
+	for it.Next() {
+		if it.i == 0 {
+			// first item of a new page
+			pages++
+			if cfg.maxPages > 0 && pages > cfg.maxPages {
+				return nil, fmt.Errorf("%w: more than %d pages", ErrListTooLarge, cfg.maxPages)
+			}
+		}
+		if cfg.maxItems > 0 && len(items) == cfg.maxItems {
+			return nil, fmt.Errorf("%w: more than %d items", ErrListTooLarge, cfg.maxItems)
+		}
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		if ctx.Err() != nil && parent.Err() == nil {
+			return nil, fmt.Errorf("%w: took longer than %s", ErrListTooLarge, cfg.maxDuration)
+		}
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,49 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestListAll(t *testing.T) {
+	e := emulator.New("token")
+	for i := 0; i < 20; i++ {
+		e.Seed("articles", map[string]any{"title": "a"})
+	}
+	var delay int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(atomic.LoadInt64(&delay)))
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+	query := DirectusQuery{Filter: Filter{"id": {OP_nnull: true}}, Sort: Fields{"id"}, Limit: 10}
+
+	all, err := articles.ListAll(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, all, 20)
+
+	// the empty third page doesn't count
+	all, err = articles.ListAll(ctx, query, MaxPages(2), MaxItems(20))
+	require.NoError(t, err)
+	require.Len(t, all, 20)
+
+	_, err = articles.ListAll(ctx, query, MaxPages(1))
+	require.ErrorIs(t, err, ErrListTooLarge)
+	_, err = articles.ListAll(ctx, query, MaxItems(19))
+	require.ErrorIs(t, err, ErrListTooLarge)
+
+	atomic.StoreInt64(&delay, int64(50*time.Millisecond))
+	_, err = articles.ListAll(ctx, query, MaxDuration(75*time.Millisecond))
+	require.ErrorIs(t, err, ErrListTooLarge)
+}
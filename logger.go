@@ -0,0 +1,42 @@
+package directus_client
+
+import "github.com/rs/zerolog"
+
+// Logger receives the few log lines of the package: cache write failures,
+// failovers, retries and the like. keysAndValues alternate between string
+// keys and arbitrary values, so *slog.Logger implements it as is.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+	Error(msg string, keysAndValues ...any)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+type zerologLogger struct {
+	l zerolog.Logger
+}
+
+// NewZerologLogger adapts a zerolog.Logger, e.g. log.Logger to keep the
+// output of earlier versions, which logged to the zerolog global.
+func NewZerologLogger(l zerolog.Logger) Logger {
+	return zerologLogger{l}
+}
+
+func (z zerologLogger) Debug(msg string, kv ...any) { z.l.Debug().Fields(kv).Msg(msg) }
+func (z zerologLogger) Info(msg string, kv ...any)  { z.l.Info().Fields(kv).Msg(msg) }
+func (z zerologLogger) Warn(msg string, kv ...any)  { z.l.Warn().Fields(kv).Msg(msg) }
+func (z zerologLogger) Error(msg string, kv ...any) { z.l.Error().Fields(kv).Msg(msg) }
+
+// WithLogger sets the logger of the client. Nothing is logged by default.
+func WithLogger(l Logger) ClientOption {
+	return func(d *DirectusClient) {
+		d.log = l
+	}
+}
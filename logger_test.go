@@ -0,0 +1,60 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/directustest"
+)
+
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level string, msg string, kv []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, strings.TrimSpace(fmt.Sprintln(append([]any{level, msg}, kv...)...)))
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...any) { l.record("debug", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...any)  { l.record("info", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...any)  { l.record("warn", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...any) { l.record("error", msg, kv) }
+
+type failingCache struct{}
+
+func (failingCache) Get(collection, rawQuery string) ([]byte, error) { return nil, nil }
+func (failingCache) Set(collection, rawQuery string, value []byte) error {
+	return errors.New("cache is full")
+}
+
+func TestLogger(t *testing.T) {
+	l := new(recordingLogger)
+	srv := directustest.NewServer(t)
+	srv.LoadFixtures("testdata/user.json")
+	client, err := NewDirectusClient(srv.URL, directustest.Token, failingCache{}, WithLogger(l))
+	require.NoError(t, err)
+
+	resp, err := client.QueryContext(ContextWithRequestID(context.Background(), "req-1"), "GET", "user", DirectusQuery{
+		Fields: Fields{"id", "email"},
+		Filter: Filter{"email": {OP_eq: "dev@dev.io"}},
+	}, nil)
+	require.NoError(t, err)
+	ReadResult[[]user](resp)
+	require.Equal(t, []string{"warn failed to set cache error cache is full path /items/user request_id req-1"}, l.lines)
+}
+
+func TestZerologLogger(t *testing.T) {
+	var buf bytes.Buffer
+	NewZerologLogger(zerolog.New(&buf)).Warn("failed to set cache", "path", "/items/user")
+	require.JSONEq(t, `{"level":"warn","path":"/items/user","message":"failed to set cache"}`, buf.String())
+}
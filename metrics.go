@@ -0,0 +1,192 @@
+package directus_client
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the Prometheus collectors of the package. Create it once per
+// registry and share it between clients and webhook servers.
+type Metrics struct {
+	requestDuration *prometheus.HistogramVec
+	cacheRequests   *prometheus.CounterVec
+	webhookEvents   *prometheus.CounterVec
+	proxyDuration   *prometheus.HistogramVec
+
+	mu          sync.RWMutex
+	collections map[string]bool
+	// allowList makes collections fixed
+	allowList bool
+}
+
+// MetricsOption configures the Metrics created by NewMetrics.
+type MetricsOption func(*Metrics)
+
+// WithMetricsCollections limits the collection label to collections, e.g.
+// those of the schema; requests and events of other collections are counted
+// as "other". By default the first 100 collections seen get their own label,
+// since requests to a proxy and webhook events can name any collection.
+func WithMetricsCollections(collections ...string) MetricsOption {
+	return func(m *Metrics) {
+		m.allowList = true
+		for _, c := range collections {
+			m.collections[c] = true
+		}
+	}
+}
+
+// maxCollectionLabels is the number of collections labeled by name without
+// WithMetricsCollections.
+const maxCollectionLabels = 100
+
+// otherLabel replaces label values beyond the bounds of a label.
+const otherLabel = "other"
+
+// NewMetrics registers the collectors on reg:
+//
+//	directus_client_request_duration_seconds{collection,method,status}
+//	directus_client_cache_requests_total{collection,result}
+//	directus_client_webhook_events_total{collection,event}
+//	directus_client_proxy_duration_seconds{status}
+//
+// Cache results are "hit" or "miss"; the cache hit ratio is hits over all
+// cache requests. Requests failing without a response have status "error".
+// Collections, methods and events are bounded, see WithMetricsCollections;
+// unknown methods and events are labeled "other".
+func NewMetrics(reg prometheus.Registerer, opts ...MetricsOption) (*Metrics, error) {
+	m := &Metrics{
+		collections: make(map[string]bool),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "directus_client_request_duration_seconds",
+			Help:    "Duration of requests sent to Directus until the response headers arrived.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"collection", "method", "status"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "directus_client_cache_requests_total",
+			Help: "Query cache lookups by result.",
+		}, []string{"collection", "result"}),
+		webhookEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "directus_client_webhook_events_total",
+			Help: "Webhook events dispatched to observers.",
+		}, []string{"collection", "event"}),
+		proxyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "directus_client_proxy_duration_seconds",
+			Help:    "Duration of requests served by Proxy, including the response body.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"status"}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	for _, c := range []prometheus.Collector{m.requestDuration, m.cacheRequests, m.webhookEvents, m.proxyDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// WithMetrics records request, cache and proxy metrics of the client.
+func WithMetrics(m *Metrics) ClientOption {
+	return func(d *DirectusClient) {
+		d.metrics = m
+	}
+}
+
+// WithWebhookMetrics counts the events dispatched by the server.
+func WithWebhookMetrics(m *Metrics) WebhookOption {
+	return func(s *WebhookEventServer) {
+		s.metrics = m
+	}
+}
+
+// collectionLabel drops item ids from paths such as "articles/1" and
+// collections beyond the bounds of m to keep the label cardinality bounded.
+func (m *Metrics) collectionLabel(collection string) string {
+	if i := strings.IndexByte(collection, '/'); i >= 0 {
+		collection = collection[:i]
+	}
+	m.mu.RLock()
+	known, full := m.collections[collection], m.allowList || len(m.collections) >= maxCollectionLabels
+	m.mu.RUnlock()
+	switch {
+	case known:
+		return collection
+	case full:
+		return otherLabel
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.collections[collection] && len(m.collections) >= maxCollectionLabels {
+		return otherLabel
+	}
+	m.collections[collection] = true
+	return collection
+}
+
+var methodLabels = map[string]bool{
+	"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "SEARCH": true, "OPTIONS": true,
+}
+
+func methodLabel(method string) string {
+	if methodLabels[method] {
+		return method
+	}
+	return otherLabel
+}
+
+// eventLabel reduces webhook events, e.g. "items.create" or
+// "articles.items.create", to the item events.
+func eventLabel(event string) string {
+	for _, e := range []string{"items.create", "items.update", "items.delete"} {
+		if event == e || strings.HasSuffix(event, "."+e) {
+			return e
+		}
+	}
+	return otherLabel
+}
+
+func statusLabel(status int, err error) string {
+	if err != nil {
+		return "error"
+	}
+	return strconv.Itoa(status)
+}
+
+// The methods below are no-ops on a nil *Metrics.
+
+func (m *Metrics) observeRequest(collection, method string, status int, err error, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.requestDuration.WithLabelValues(m.collectionLabel(collection), methodLabel(method), statusLabel(status, err)).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) observeCache(collection string, hit bool) {
+	if m == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheRequests.WithLabelValues(m.collectionLabel(collection), result).Inc()
+}
+
+func (m *Metrics) observeWebhookEvent(e *WebhookEvent) {
+	if m == nil {
+		return
+	}
+	m.webhookEvents.WithLabelValues(m.collectionLabel(e.Collection), eventLabel(e.Event)).Inc()
+}
+
+func (m *Metrics) observeProxy(status int, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.proxyDuration.WithLabelValues(strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+}
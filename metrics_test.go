@@ -0,0 +1,81 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := NewMetrics(reg)
+	require.NoError(t, err)
+	_, err = NewMetrics(reg)
+	require.Error(t, err, "collectors are registered once")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache, WithMetrics(m))
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		ReadResult[[]user](resp)
+	}
+	w := httptest.NewRecorder()
+	client.Proxy(0).ServeHTTP(w, httptest.NewRequest("GET", "/items/user/1", nil))
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues("user", "hit")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues("user", "miss")))
+	require.Equal(t, 1, testutil.CollectAndCount(m.requestDuration), "item ids are dropped from the collection label")
+	require.Equal(t, 1, testutil.CollectAndCount(m.proxyDuration))
+}
+
+func TestMetricsLabelBounds(t *testing.T) {
+	m, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	for i := 0; i < maxCollectionLabels+50; i++ {
+		m.observeCache("c"+strconv.Itoa(i), false)
+	}
+	// the bound is shared by all metrics
+	for i := 0; i < maxCollectionLabels+50; i++ {
+		m.observeWebhookEvent(&WebhookEvent{Collection: "w" + strconv.Itoa(i), Event: "forged" + strconv.Itoa(i)})
+	}
+	require.Equal(t, maxCollectionLabels+1, testutil.CollectAndCount(m.cacheRequests))
+	require.Equal(t, 50.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues(otherLabel, "miss")))
+	require.Equal(t, 150.0, testutil.ToFloat64(m.webhookEvents.WithLabelValues(otherLabel, otherLabel)))
+
+	m, err = NewMetrics(prometheus.NewRegistry(), WithMetricsCollections("user"))
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithMetrics(m))
+	require.NoError(t, err)
+	proxy := client.Proxy(0)
+	for _, target := range []string{"/items/user/1", "/items/a", "/items/b"} {
+		proxy.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	}
+	m.observeWebhookEvent(&WebhookEvent{Collection: "user", Event: "user.items.update"})
+
+	// user and other
+	require.Equal(t, 2, testutil.CollectAndCount(m.requestDuration))
+	require.Equal(t, "GET", methodLabel("GET"))
+	require.Equal(t, otherLabel, methodLabel("BREW"))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.webhookEvents.WithLabelValues("user", "items.update")))
+}
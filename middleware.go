@@ -0,0 +1,23 @@
+package directus_client
+
+import "net/http"
+
+// RoundTripFunc sends a request prepared by Call and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the RoundTripFunc that serves a request.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middleware to the chain every Call, Query and Proxy request
+// goes through. Middleware sees requests once their URL and headers are set,
+// before the cache is consulted, so it also observes cache hits and request
+// mutations change the cache key. The first middleware added is the
+// outermost. Use must not be called concurrently with requests.
+func (d *DirectusClient) Use(mw ...Middleware) {
+	d.middleware = append(d.middleware, mw...)
+	next := d.roundTrip
+	for i := len(d.middleware) - 1; i >= 0; i-- {
+		next = d.middleware[i](next)
+	}
+	d.chain = next
+}
@@ -0,0 +1,57 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "tenant-a", r.Header.Get("X-Tenant"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+
+	var calls []string
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, "outer "+req.Header.Get("Authorization"))
+			return next(req)
+		}
+	}, func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Tenant", "tenant-a")
+			resp, err := next(req)
+			calls = append(calls, "inner "+resp.Status)
+			return resp, err
+		}
+	})
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+
+	w := httptest.NewRecorder()
+	client.Proxy(0).ServeHTTP(w, httptest.NewRequest("GET", "/items/user?"+mustEncode(t, query), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// the second query and the proxied one are cache hits
+	require.Equal(t, []string{
+		"outer Bearer token", "inner 200 OK",
+		"outer Bearer token", "inner 200 OK",
+		"outer Bearer token", "inner 200 OK",
+	}, calls)
+}
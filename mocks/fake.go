@@ -0,0 +1,123 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	directus_client "gitlab.enkuchat.com/backend/directus_client"
+)
+
+type cannedResponse struct {
+	status int
+	body   []byte
+}
+
+// FakeQuerier is an in-memory directus_client.Querier serving canned
+// DirectusResult payloads per collection. Unlike Querier it needs no
+// expectations, which suits tests that only care about decoded data.
+type FakeQuerier struct {
+	mu        sync.RWMutex
+	responses map[string]cannedResponse
+	requests  []*http.Request
+}
+
+var _ directus_client.Querier = (*FakeQuerier)(nil)
+
+func NewFakeQuerier() *FakeQuerier {
+	return &FakeQuerier{responses: make(map[string]cannedResponse)}
+}
+
+// SetData serves data wrapped in a DirectusResult for every request to collection.
+func (f *FakeQuerier) SetData(collection string, data any) error {
+	return f.SetResult(collection, directus_client.DirectusResult[any]{Data: data})
+}
+
+// SetResult serves result as is, which allows faking meta and errors.
+func (f *FakeQuerier) SetResult(collection string, result directus_client.DirectusResult[any]) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	status := http.StatusOK
+	if result.Err() {
+		status = http.StatusBadRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[collection] = cannedResponse{status, b}
+	return nil
+}
+
+// SetStatus makes every request to collection fail with status.
+func (f *FakeQuerier) SetStatus(collection string, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[collection] = cannedResponse{status: status}
+}
+
+// Requests returns the requests received so far, in order.
+func (f *FakeQuerier) Requests() []*http.Request {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]*http.Request(nil), f.requests...)
+}
+
+func (f *FakeQuerier) Call(req *http.Request) (*http.Response, error) {
+	return f.CallContext(req.Context(), req)
+}
+
+// CallContext fails with the error of ctx once it is done, like a request
+// cancelled in flight.
+func (f *FakeQuerier) CallContext(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.URL == nil {
+		return nil, errors.New("url is required")
+	}
+	split := strings.SplitN(req.URL.Path, "items/", 2)
+	if len(split) != 2 {
+		return nil, errors.New("invalid url")
+	}
+	collection := split[1]
+
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	canned, ok := f.responses[collection]
+	f.mu.Unlock()
+
+	if !ok {
+		canned = cannedResponse{status: http.StatusNotFound}
+	}
+	return &http.Response{
+		Status:     http.StatusText(canned.status),
+		StatusCode: canned.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(canned.body)),
+		Request:    req,
+	}, nil
+}
+
+func (f *FakeQuerier) Query(method string, collection string, query directus_client.DirectusQuery, input io.Reader) (*http.Response, error) {
+	return f.QueryContext(context.Background(), method, collection, query, input)
+}
+
+func (f *FakeQuerier) QueryContext(ctx context.Context, method string, collection string, query directus_client.DirectusQuery, input io.Reader) (*http.Response, error) {
+	v, err := query.BuildQuery()
+	if err != nil {
+		return nil, err
+	}
+	u := &url.URL{Path: "/items/" + collection, RawQuery: v.Encode()}
+	r := (&http.Request{Method: method, URL: u, Header: http.Header{}}).WithContext(ctx)
+	if input != nil {
+		r.Body = io.NopCloser(input)
+	}
+	return f.CallContext(ctx, r)
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	directus_client "gitlab.enkuchat.com/backend/directus_client"
+)
+
+type user struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+func TestFakeQuerier(t *testing.T) {
+	fake := NewFakeQuerier()
+	require.NoError(t, fake.SetData("user", []user{{1, "dev@dev.io"}}))
+
+	var q directus_client.Querier = fake
+	resp, err := q.Query("GET", "user", directus_client.DirectusQuery{Fields: directus_client.Fields{"id", "email"}}, nil)
+	require.NoError(t, err)
+
+	result := directus_client.ReadResult[[]user](resp)
+	require.False(t, result.Err())
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	require.Len(t, fake.Requests(), 1)
+	require.Equal(t, "id,email", fake.Requests()[0].URL.Query().Get("fields"))
+
+	resp, err = q.Query("GET", "missing", directus_client.DirectusQuery{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = q.QueryContext(ctx, "GET", "user", directus_client.DirectusQuery{}, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMockQuerier(t *testing.T) {
+	m := NewQuerier(t)
+	m.On("Call", (*http.Request)(nil)).Return(nil, http.ErrServerClosed)
+
+	_, err := m.Call(nil)
+	require.ErrorIs(t, err, http.ErrServerClosed)
+}
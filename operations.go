@@ -0,0 +1,32 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Operation is a step of a Directus flow, e.g. of Type "item-create",
+// "request" or "exec". Resolve and Reject are the ids of the operations run
+// after it succeeds or fails, which makes the operations of a flow a graph;
+// PositionX and PositionY place it on the grid of the flow editor.
+type Operation struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Key         string          `json:"key,omitempty"`
+	Type        string          `json:"type,omitempty"`
+	PositionX   int             `json:"position_x,omitempty"`
+	PositionY   int             `json:"position_y,omitempty"`
+	Options     json.RawMessage `json:"options,omitempty"`
+	Resolve     string          `json:"resolve,omitempty"`
+	Reject      string          `json:"reject,omitempty"`
+	Flow        string          `json:"flow,omitempty"`
+	DateCreated *time.Time      `json:"date_created,omitempty"`
+	UserCreated string          `json:"user_created,omitempty"`
+}
+
+// Operations returns a client for the operations of flows at /operations.
+// Create the operations of a flow from the last to the first, so each can
+// refer to its successors, then set the first as the Operation of the flow.
+func (d *DirectusClient) Operations() *SystemClient[Operation] {
+	return System[Operation](d, "/operations")
+}
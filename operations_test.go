@@ -0,0 +1,53 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperations(t *testing.T) {
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /operations":
+			body["id"] = body["key"]
+		case "PATCH /flows/f1":
+			body["id"] = "f1"
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"data": body})
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	notify, err := client.Operations().Create(ctx, Operation{
+		Name: "Notify", Key: "notify", Type: "notification", Flow: "f1",
+		Options: json.RawMessage(`{"recipient":["u1"]}`),
+	})
+	require.NoError(t, err)
+	update, err := client.Operations().Create(ctx, Operation{
+		Name: "Publish", Key: "publish", Type: "item-update", Flow: "f1", Resolve: notify.ID, PositionX: 19, PositionY: 1,
+	})
+	require.NoError(t, err)
+	flow, err := client.Flows().Update(ctx, "f1", Flow{Operation: update.ID})
+	require.NoError(t, err)
+	require.Equal(t, "publish", flow.Operation)
+
+	require.Equal(t, []map[string]any{
+		{"id": "notify", "name": "Notify", "key": "notify", "type": "notification", "flow": "f1", "options": map[string]any{"recipient": []any{"u1"}}},
+		{"id": "publish", "name": "Publish", "key": "publish", "type": "item-update", "flow": "f1", "resolve": "notify", "position_x": float64(19), "position_y": float64(1)},
+		{"id": "f1", "operation": "publish"},
+	}, bodies)
+}
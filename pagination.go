@@ -0,0 +1,155 @@
+package directus_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// PaginationStrategy is how a query selects the window of items it returns.
+type PaginationStrategy int
+
+const (
+	// PageByOffset skips the first Offset items.
+	PageByOffset PaginationStrategy = iota
+	// PageByNumber returns page Page, counting from 1, of limit items each.
+	PageByNumber
+	// PageByCursor returns the items after the Field value After of the last
+	// item seen, in the order of Field. Unlike offsets, the database seeks
+	// to the cursor instead of counting skipped rows, so deep pages stay
+	// fast and items created meanwhile don't shift them. Field must be
+	// unique, e.g. the primary key, or items sharing a value are skipped.
+	PageByCursor
+)
+
+// Pagination selects the window of items of a query, set with
+// DirectusQuery.Paginate.
+type Pagination struct {
+	Strategy PaginationStrategy
+	Offset   int
+	Page     int
+	// Field is the field of PageByCursor, prefixed with "-" for
+	// descending order.
+	Field string
+	// After is the value of Field of the last item seen, nil for the first
+	// page.
+	After any
+}
+
+// OffsetPagination skips the first offset items.
+func OffsetPagination(offset int) Pagination {
+	return Pagination{Strategy: PageByOffset, Offset: offset}
+}
+
+// PagePagination selects page number page, counting from 1.
+func PagePagination(page int) Pagination {
+	return Pagination{Strategy: PageByNumber, Page: page}
+}
+
+// CursorPagination selects the items following after in the order of field,
+// e.g. CursorPagination("id", 120) or CursorPagination("-date_created", nil).
+func CursorPagination(field string, after any) Pagination {
+	return Pagination{Strategy: PageByCursor, Field: field, After: after}
+}
+
+// Paginate replaces the offset, page or cursor of d with p.
+func (d *DirectusQuery) Paginate(p Pagination) {
+	d.Offset, d.offsetIsSet = 0, false
+	d.Page, d.pageIsSet = 0, false
+	d.cursor = nil
+	switch p.Strategy {
+	case PageByOffset:
+		d.Offset, d.offsetIsSet = p.Offset, true
+	case PageByNumber:
+		d.Page, d.pageIsSet = p.Page, true
+	case PageByCursor:
+		d.cursor = &p
+	}
+}
+
+// Pagination returns the pagination of d, offset 0 if none was set.
+func (d *DirectusQuery) Pagination() Pagination {
+	switch {
+	case d.cursor != nil:
+		return *d.cursor
+	case d.pageIsSet:
+		return PagePagination(d.Page)
+	}
+	return OffsetPagination(d.Offset)
+}
+
+func (p *Pagination) validate() error {
+	switch p.Strategy {
+	case PageByOffset:
+		if p.Offset < 0 {
+			return errors.New("offset must not be negative")
+		}
+	case PageByNumber:
+		if p.Page < 1 {
+			return errors.New("page must be at least 1")
+		}
+	case PageByCursor:
+		if !validFieldName(strings.TrimPrefix(p.Field, "-")) {
+			return errors.New("invalid cursor field: " + strconv.Quote(p.Field))
+		}
+	}
+	return nil
+}
+
+// cursorQuery returns the filter and sort of d with its cursor applied: the
+// cursor field sorted first and, past the first page, a condition selecting
+// the items after the cursor.
+func (d *DirectusQuery) cursorQuery() (FilterNode, Fields) {
+	c := d.cursor
+	field := strings.TrimPrefix(c.Field, "-")
+	sort := Fields{c.Field}
+	for _, s := range d.Sort {
+		if strings.TrimPrefix(s, "-") != field {
+			sort = append(sort, s)
+		}
+	}
+	if c.After == nil {
+		return d.Filter, sort
+	}
+	op := OP_gt
+	if strings.HasPrefix(c.Field, "-") {
+		op = OP_lt
+	}
+	after := Filter{field: {op: c.After}}
+	if filterEmpty(d.Filter) {
+		return after, sort
+	}
+	return And{d.Filter, after}, sort
+}
+
+// cursorValue returns the value of the dotted field path of item, keeping
+// numbers exact.
+func cursorValue(item any, path string) (any, error) {
+	b, err := currentCodec().Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	raw := json.RawMessage(b)
+	for _, segment := range strings.Split(path, ".") {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, errors.New("cursor field " + strconv.Quote(path) + " is not in the items")
+		}
+		var ok bool
+		if raw, ok = obj[segment]; !ok {
+			return nil, errors.New("cursor field " + strconv.Quote(path) + " is not in the items")
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, errors.New("cursor field " + strconv.Quote(path) + " is null")
+	}
+	return v, nil
+}
@@ -0,0 +1,75 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestPagination(t *testing.T) {
+	query := DirectusQuery{Filter: Filter{"status": {OP_eq: "published"}}, Sort: Fields{"title", "-id"}, Limit: 10}
+	require.Equal(t, OffsetPagination(0), query.Pagination())
+
+	query.Paginate(OffsetPagination(20))
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "20", v.Get("offset"))
+
+	query.Paginate(PagePagination(3))
+	require.Equal(t, PagePagination(3), query.Pagination())
+	v, err = query.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "3", v.Get("page"))
+	require.Empty(t, v.Get("offset"))
+
+	query.Paginate(CursorPagination("-id", json.Number("120")))
+	v, err = query.BuildQuery()
+	require.NoError(t, err)
+	require.Empty(t, v.Get("page"))
+	require.Equal(t, "-id,title", v.Get("sort"))
+	require.JSONEq(t, `{"_and":[{"status":{"_eq":"published"}},{"id":{"_lt":120}}]}`, v.Get("filter"))
+	require.Equal(t, Fields{"title", "-id"}, query.Sort)
+
+	for _, bad := range []Pagination{OffsetPagination(-1), PagePagination(0), CursorPagination("a b", nil)} {
+		q := DirectusQuery{}
+		q.Paginate(bad)
+		require.Error(t, q.validate(), bad)
+	}
+}
+
+func TestIterateCursor(t *testing.T) {
+	e := emulator.New("token")
+	for i := 0; i < 25; i++ {
+		e.Seed("articles", map[string]any{"title": "a"})
+	}
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	query := DirectusQuery{Limit: 10}
+	query.Paginate(CursorPagination("-id", nil))
+	it := Items[article](client, "articles").Iterate(context.Background(), query)
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, ids, 25)
+	require.Equal(t, 25, ids[0])
+	require.Equal(t, 1, ids[24])
+	require.Len(t, queries, 3)
+	require.Empty(t, queries[0].Get("filter"))
+	require.JSONEq(t, `{"id":{"_lt":6}}`, queries[2].Get("filter"))
+	require.Empty(t, queries[2].Get("offset"))
+}
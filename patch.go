@@ -0,0 +1,92 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UpdateFields patches the item with primary key id with only the given
+// fields of item, named as in JSON, so columns missing from a partially
+// loaded item are not overwritten. Without fields, the non-zero fields of
+// item are sent, the primary key excepted; use pointer fields to write zero
+// values.
+func (c *ItemsClient[T]) UpdateFields(ctx context.Context, id any, item T, fields ...string) (T, error) {
+	patch, err := fieldMask(item, fields, c.primaryKey)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return c.Update(ctx, id, patch)
+}
+
+// fieldMask encodes fields of item, or its non-zero fields without
+// primaryKey, as a JSON object.
+func fieldMask(item any, fields []string, primaryKey string) (map[string]json.RawMessage, error) {
+	b, err := currentCodec().Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := currentCodec().Unmarshal(b, &all); err != nil {
+		return nil, fmt.Errorf("directus: %T is not encoded as an object", item)
+	}
+	if len(fields) == 0 {
+		v := reflect.Indirect(reflect.ValueOf(item))
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("directus: fields are required for %T", item)
+		}
+		for _, f := range nonZeroFields(v) {
+			if f != primaryKey {
+				fields = append(fields, f)
+			}
+		}
+	}
+	patch := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		raw, ok := all[f]
+		if !ok {
+			return nil, fmt.Errorf("directus: no field %q in %T", f, item)
+		}
+		patch[f] = raw
+	}
+	return patch, nil
+}
+
+// nonZeroFields returns the JSON names of the non-zero fields of struct v,
+// including those of embedded structs.
+func nonZeroFields(v reflect.Value) []string {
+	var names []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" || (!sf.IsExported() && !sf.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+		if sf.Anonymous && name == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				names = append(names, nonZeroFields(fv)...)
+				continue
+			}
+		}
+		if !sf.IsExported() || fv.IsZero() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,55 @@
+package directus_client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type timestamps struct {
+	UpdatedAt string `json:"date_updated,omitempty"`
+}
+
+type draft struct {
+	ID     int     `json:"id"`
+	Title  string  `json:"title"`
+	Status string  `json:"status"`
+	Views  *int    `json:"views"`
+	Note   *string `json:"note"`
+	secret string
+	timestamps
+}
+
+func TestUpdateFields(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/items/drafts/7", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":7,"title":"a","status":"draft"}}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	drafts := Items[draft](client, "drafts")
+	ctx := context.Background()
+
+	zero := 0
+	item := draft{ID: 7, Title: "a", Views: &zero, secret: "s", timestamps: timestamps{"2022-06-01"}}
+	_, err = drafts.UpdateFields(ctx, 7, item, "title", "status")
+	require.NoError(t, err)
+	_, err = drafts.UpdateFields(ctx, 7, item)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`{"status":"","title":"a"}`,
+		`{"date_updated":"2022-06-01","title":"a","views":0}`,
+	}, bodies)
+
+	_, err = drafts.UpdateFields(ctx, 7, item, "unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Permission grants a role, up to Directus 10, or a policy, from Directus 11
+// on, an action on a collection. Permissions and Validation are filters
+// limiting the items the action applies to and the values it accepts;
+// Presets are default values.
+type Permission struct {
+	ID          int             `json:"id,omitempty"`
+	Role        string          `json:"role,omitempty"`
+	Policy      string          `json:"policy,omitempty"`
+	Collection  string          `json:"collection,omitempty"`
+	Action      string          `json:"action,omitempty"`
+	Permissions json.RawMessage `json:"permissions,omitempty"`
+	Validation  json.RawMessage `json:"validation,omitempty"`
+	Presets     json.RawMessage `json:"presets,omitempty"`
+	Fields      []string        `json:"fields,omitempty"`
+}
+
+// Actions of permissions.
+const (
+	ActionCreate = "create"
+	ActionRead   = "read"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionShare  = "share"
+)
+
+// Policy is an access policy of Directus 11, granting permissions and access
+// to the roles and users it is attached to.
+type Policy struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Description string   `json:"description,omitempty"`
+	IPAccess    []string `json:"ip_access,omitempty"`
+	EnforceTFA  bool     `json:"enforce_tfa,omitempty"`
+	AdminAccess bool     `json:"admin_access,omitempty"`
+	AppAccess   bool     `json:"app_access,omitempty"`
+}
+
+// Policies returns a client for the access policies of Directus 11 at
+// /policies.
+func (d *DirectusClient) Policies() *SystemClient[Policy] {
+	return System[Policy](d, "/policies")
+}
+
+// PermissionsClient manages permissions at /permissions.
+type PermissionsClient struct {
+	*SystemClient[Permission]
+}
+
+// Permissions returns a client for the permissions of Directus.
+func (d *DirectusClient) Permissions() *PermissionsClient {
+	return &PermissionsClient{System[Permission](d, "/permissions")}
+}
+
+// Access levels of an EffectivePermission.
+const (
+	AccessNone    = "none"
+	AccessPartial = "partial"
+	AccessFull    = "full"
+)
+
+// EffectivePermission is the access of the caller for an action on a
+// collection: to all items, to some, limited by a filter, or none.
+type EffectivePermission struct {
+	Access  string          `json:"access"`
+	Fields  []string        `json:"fields,omitempty"`
+	Presets json.RawMessage `json:"presets,omitempty"`
+}
+
+// EffectivePermissions are the permissions of the caller by collection and
+// action.
+type EffectivePermissions map[string]map[string]EffectivePermission
+
+// Can reports whether the caller may perform action on some items of
+// collection at least.
+func (p EffectivePermissions) Can(collection string, action string) bool {
+	access := p[collection][action].Access
+	return access == AccessPartial || access == AccessFull
+}
+
+// Me returns the effective permissions of the caller, e.g. to hide what
+// the UI can't do anyway. Directus 11 reports them by collection and action;
+// the list of permissions of earlier versions is converted, with access
+// partial for permissions with a filter.
+func (c *PermissionsClient) Me(ctx context.Context) (EffectivePermissions, error) {
+	var raw json.RawMessage
+	if err := c.d.sendJSON(ctx, "GET", "/permissions/me", nil, nil, &raw); err != nil {
+		return nil, err
+	}
+	var list []Permission
+	if err := currentCodec().Unmarshal(raw, &list); err != nil {
+		var p EffectivePermissions
+		if err := currentCodec().Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	p := EffectivePermissions{}
+	for _, perm := range list {
+		if p[perm.Collection] == nil {
+			p[perm.Collection] = map[string]EffectivePermission{}
+		}
+		access := AccessFull
+		if !filterless(perm.Permissions) {
+			access = AccessPartial
+		}
+		p[perm.Collection][perm.Action] = EffectivePermission{Access: access, Fields: perm.Fields, Presets: perm.Presets}
+	}
+	return p, nil
+}
+
+// filterless reports whether the permission filter raw matches all items.
+func filterless(raw json.RawMessage) bool {
+	switch string(raw) {
+	case "", "null", "{}":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissions(t *testing.T) {
+	me := `[
+		{"id":1,"collection":"articles","action":"read","permissions":{},"fields":["*"]},
+		{"id":2,"collection":"articles","action":"update","permissions":{"author":{"_eq":"$CURRENT_USER"}},"fields":["title"]}
+	]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /permissions/me":
+			w.Write([]byte(`{"data":` + me + `}`))
+		case "POST /permissions":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, map[string]any{
+				"policy":      "p1",
+				"collection":  "articles",
+				"action":      "read",
+				"permissions": map[string]any{"status": map[string]any{"_eq": "published"}},
+				"fields":      []any{"*"},
+			}, body)
+			body["id"] = 3
+			json.NewEncoder(w).Encode(map[string]any{"data": body})
+		case "GET /policies":
+			w.Write([]byte(`{"data":[{"id":"p1","name":"Public","app_access":false}]}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	filter, err := json.Marshal(filterJSON(Filter{"status": {OP_eq: "published"}}))
+	require.NoError(t, err)
+	created, err := client.Permissions().Create(ctx, Permission{Policy: "p1", Collection: "articles", Action: ActionRead, Permissions: filter, Fields: []string{"*"}})
+	require.NoError(t, err)
+	require.Equal(t, 3, created.ID)
+
+	policies, _, err := client.Policies().List(ctx, DirectusQuery{})
+	require.NoError(t, err)
+	require.Equal(t, []Policy{{ID: "p1", Name: "Public"}}, policies)
+
+	// Directus 10 lists permissions
+	p, err := client.Permissions().Me(ctx)
+	require.NoError(t, err)
+	require.Equal(t, AccessFull, p["articles"][ActionRead].Access)
+	require.Equal(t, AccessPartial, p["articles"][ActionUpdate].Access)
+	require.True(t, p.Can("articles", ActionUpdate))
+	require.False(t, p.Can("articles", ActionDelete))
+	require.False(t, p.Can("comments", ActionRead))
+
+	// Directus 11 reports access by collection and action
+	me = `{"articles":{"read":{"access":"full","fields":["*"]},"delete":{"access":"none"}}}`
+	p, err = client.Permissions().Me(ctx)
+	require.NoError(t, err)
+	require.True(t, p.Can("articles", ActionRead))
+	require.False(t, p.Can("articles", ActionDelete))
+	require.Equal(t, []string{"*"}, p["articles"][ActionRead].Fields)
+}
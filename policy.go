@@ -0,0 +1,202 @@
+package directus_client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrQueryRejected is matched by errors.Is against queries a QueryPolicy
+// rejects.
+var ErrQueryRejected = errors.New("directus: query rejected by policy")
+
+// QueryPolicy bounds the cost of item queries, e.g. those a public proxy
+// receives, before they reach Directus. Zero bounds are not enforced.
+type QueryPolicy struct {
+	// MaxDepth is the number of relations a field, filter, sort, groupBy,
+	// alias or aggregate path may traverse: 1 allows "author.name" and "*.*"
+	// but not "author.role.name".
+	MaxDepth int
+	// MaxFields is the number of fields a query may request.
+	MaxFields int
+	// MaxLimit is the number of items a query may request; queries without
+	// a limit are given this one.
+	MaxLimit int
+	// ClampLimit lowers larger limits, ITEMS_NO_LIMIT included, to MaxLimit
+	// instead of rejecting the query.
+	ClampLimit bool
+}
+
+// WithQueryPolicy applies p to the queries of Query, QueryContext, QueryTo,
+// Export, Count and the ItemsClient methods built on them.
+func WithQueryPolicy(p QueryPolicy) ClientOption {
+	return func(d *DirectusClient) {
+		d.policy = &p
+	}
+}
+
+// WithProxyQueryPolicy applies p to the queries of proxied GET and HEAD
+// requests, which fail with 400 Bad Request if rejected. Their parameters
+// must pass ParseQuery; others, such as deep, and repeated ones are refused.
+// SEARCH requests are refused since their query is in the body; mutations
+// are passed on as they are.
+func WithProxyQueryPolicy(p QueryPolicy) ProxyOption {
+	return func(c *proxyConfig) {
+		c.policy = &p
+	}
+}
+
+// Apply rewrites q to comply with p or returns an error wrapping
+// ErrQueryRejected if it can't. A zero limit counts as unset.
+func (p *QueryPolicy) Apply(q *DirectusQuery) error {
+	if p.MaxLimit > 0 {
+		switch {
+		case q.Limit == 0:
+			q.Limit = p.MaxLimit
+		case q.Limit > p.MaxLimit || q.Limit == ITEMS_NO_LIMIT:
+			if !p.ClampLimit {
+				return fmt.Errorf("%w: limit %d, at most %d allowed", ErrQueryRejected, q.Limit, p.MaxLimit)
+			}
+			q.Limit = p.MaxLimit
+		}
+	}
+	if p.MaxFields > 0 && len(q.Fields) > p.MaxFields {
+		return fmt.Errorf("%w: %d fields, at most %d allowed", ErrQueryRejected, len(q.Fields), p.MaxFields)
+	}
+	if p.MaxDepth > 0 {
+		if path := deepestPath(q); relationDepth(path) > p.MaxDepth {
+			return fmt.Errorf("%w: %s traverses %d relations, at most %d allowed", ErrQueryRejected, strconv.Quote(path), relationDepth(path), p.MaxDepth)
+		}
+	}
+	return nil
+}
+
+// relationDepth is the number of relations path traverses.
+func relationDepth(path string) int {
+	return strings.Count(path, ".")
+}
+
+// deepestPath returns the path of q traversing the most relations.
+func deepestPath(q *DirectusQuery) string {
+	var deepest string
+	visit := func(path string) {
+		path = strings.TrimPrefix(path, "-")
+		if relationDepth(path) > relationDepth(deepest) {
+			deepest = path
+		}
+	}
+	for _, fields := range []Fields{q.Fields, q.Sort, q.GroupBy} {
+		for _, f := range fields {
+			visit(f)
+		}
+	}
+	for _, f := range q.Alias {
+		visit(f)
+	}
+	for _, fields := range q.Aggregate {
+		for _, f := range fields {
+			visit(f)
+		}
+	}
+	filterPaths(q.Filter, visit)
+	return deepest
+}
+
+// filterPaths calls fn with the field paths of f.
+func filterPaths(f FilterNode, fn func(string)) {
+	switch f := f.(type) {
+	case Filter:
+		for path := range f {
+			fn(path)
+		}
+	case And:
+		for _, n := range f {
+			filterPaths(n, fn)
+		}
+	case Or:
+		for _, n := range f {
+			filterPaths(n, fn)
+		}
+	}
+}
+
+// applyProxyPolicy applies p to the query of the proxied request r.
+func applyProxyPolicy(p *QueryPolicy, r *http.Request) error {
+	switch r.Method {
+	case "GET", "HEAD":
+	case "SEARCH":
+		return fmt.Errorf("%w: SEARCH requests are not allowed", ErrQueryRejected)
+	default:
+		return nil
+	}
+	v := r.URL.Query()
+	if err := checkProxyParams(v); err != nil {
+		return err
+	}
+	q, err := ParseQuery(v)
+	if err != nil {
+		return err
+	}
+	if v.Get("limit") == "" {
+		q.Limit = 0
+	}
+	limit := q.Limit
+	if err := p.Apply(q); err != nil {
+		return err
+	}
+	if q.Limit != limit {
+		v.Set("limit", strconv.Itoa(q.Limit))
+		r.URL.RawQuery = v.Encode()
+	}
+	return nil
+}
+
+// checkProxyParams rejects the parameters of proxied requests that
+// ParseQuery, and so the policy, doesn't see: unknown ones such as deep,
+// whose nested limits and filters can't be bounded, and repeated ones,
+// which Directus reads as lists while ParseQuery only reads the first.
+func checkProxyParams(v url.Values) error {
+	for k, values := range v {
+		switch {
+		case k == "groupBy" || k == "groupBy[]" || strings.HasPrefix(k, "filter["):
+			// may be repeated
+			continue
+		case proxyParams[k] || strings.HasPrefix(k, "aggregate[") || strings.HasPrefix(k, "alias["):
+		default:
+			return fmt.Errorf("%w: parameter %s is not allowed", ErrQueryRejected, strconv.Quote(k))
+		}
+		if len(values) > 1 {
+			return fmt.Errorf("%w: parameter %s is repeated", ErrQueryRejected, strconv.Quote(k))
+		}
+	}
+	return nil
+}
+
+// proxyParams are the single-valued parameters checkProxyParams passes on.
+var proxyParams = map[string]bool{
+	"fields":       true,
+	"filter":       true,
+	"sort":         true,
+	"limit":        true,
+	"offset":       true,
+	"page":         true,
+	"meta":         true,
+	"search":       true,
+	"export":       true,
+	"version":      true,
+	"versionRaw":   true,
+	"access_token": true,
+}
+
+// checkQuery applies the query policy of d to q and validates it.
+func (d *DirectusClient) checkQuery(q *DirectusQuery) error {
+	if d.policy != nil {
+		if err := d.policy.Apply(q); err != nil {
+			return err
+		}
+	}
+	return q.validateLimit(d.maxLimit)
+}
@@ -0,0 +1,105 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestQueryPolicy(t *testing.T) {
+	p := QueryPolicy{MaxDepth: 1, MaxFields: 3, MaxLimit: 100}
+	for _, c := range []struct {
+		query   DirectusQuery
+		wantErr string
+		limit   int
+	}{
+		{DirectusQuery{Fields: Fields{"id", "author.name", "*.*"}}, "", 100},
+		{DirectusQuery{Limit: 50, Sort: Fields{"-author.name"}}, "", 50},
+		{DirectusQuery{Limit: 101}, "directus: query rejected by policy: limit 101, at most 100 allowed", 0},
+		{DirectusQuery{Limit: ITEMS_NO_LIMIT}, "directus: query rejected by policy: limit -1, at most 100 allowed", 0},
+		{DirectusQuery{Fields: Fields{"a", "b", "c", "d"}}, "directus: query rejected by policy: 4 fields, at most 3 allowed", 0},
+		{DirectusQuery{Fields: Fields{"*.*.*"}}, `directus: query rejected by policy: "*.*.*" traverses 2 relations, at most 1 allowed`, 0},
+		{DirectusQuery{Filter: Or{Filter{"author.role.name": {OP_eq: "x"}}}}, `directus: query rejected by policy: "author.role.name" traverses 2 relations, at most 1 allowed`, 0},
+		{DirectusQuery{Sort: Fields{"-author.role.name"}}, `directus: query rejected by policy: "author.role.name" traverses 2 relations, at most 1 allowed`, 0},
+	} {
+		q := c.query
+		err := p.Apply(&q)
+		if c.wantErr == "" {
+			require.NoError(t, err)
+			require.Equal(t, c.limit, q.Limit)
+		} else {
+			require.EqualError(t, err, c.wantErr)
+			require.ErrorIs(t, err, ErrQueryRejected)
+		}
+	}
+
+	p.ClampLimit = true
+	q := DirectusQuery{Limit: ITEMS_NO_LIMIT}
+	require.NoError(t, p.Apply(&q))
+	require.Equal(t, 100, q.Limit)
+}
+
+func TestQueryPolicyClient(t *testing.T) {
+	e := emulator.New("token")
+	for i := 0; i < 25; i++ {
+		e.Seed("articles", map[string]any{"title": "a"})
+	}
+	var limits []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		e.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithQueryPolicy(QueryPolicy{MaxLimit: 10, ClampLimit: true, MaxDepth: 1}))
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	// iteration goes on past clamped pages
+	list, err := articles.ListAll(ctx, DirectusQuery{})
+	require.NoError(t, err)
+	require.Len(t, list, 25)
+	require.Equal(t, []string{"10", "10", "10"}, limits)
+
+	_, _, err = articles.List(ctx, DirectusQuery{Fields: Fields{"author.role.name"}})
+	require.ErrorIs(t, err, ErrQueryRejected)
+
+	proxy := client.Proxy(0, WithProxyQueryPolicy(QueryPolicy{MaxLimit: 5, MaxFields: 2, MaxDepth: 1}))
+	for raw, want := range map[string]int{
+		"":                                       http.StatusOK,
+		"limit=3":                                http.StatusOK,
+		"limit=50":                               http.StatusBadRequest,
+		"fields=id,title,body":                   http.StatusBadRequest,
+		"fields=a%20b":                           http.StatusBadRequest,
+		"groupBy=a.b":                            http.StatusOK,
+		"groupBy=a.b.c":                          http.StatusBadRequest,
+		"alias%5Bx%5D=a.b.c":                     http.StatusBadRequest,
+		"aggregate%5Bcount%5D=a.b.c":             http.StatusBadRequest,
+		"filter%5Ba%5D%5Bb%5D%5Bc%5D%5B_eq%5D=1": http.StatusBadRequest,
+		"deep%5Brel%5D%5B_limit%5D=-1":           http.StatusBadRequest,
+		"limit=3&limit=-1":                       http.StatusBadRequest,
+		"fields=id&fields=a.b.c":                 http.StatusBadRequest,
+		"unknown=1":                              http.StatusBadRequest,
+	} {
+		limits = nil
+		w := httptest.NewRecorder()
+		proxy.ServeHTTP(w, httptest.NewRequest("GET", "/items/articles?"+raw, nil))
+		require.Equal(t, want, w.Code, raw)
+		if want == http.StatusOK {
+			q, _ := url.ParseQuery(raw)
+			wantLimit := q.Get("limit")
+			if wantLimit == "" {
+				wantLimit = "5"
+			}
+			require.Equal(t, []string{wantLimit}, limits, raw)
+		}
+	}
+	w := httptest.NewRecorder()
+	proxy.ServeHTTP(w, httptest.NewRequest("SEARCH", "/items/articles", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,44 @@
+package directus_client
+
+import "encoding/json"
+
+// Preset is a Directus preset: the layout and filters of a collection in the
+// app, either a bookmark, if Bookmark names it, or the default of a user, a
+// role or everyone, if User and Role are empty. LayoutQuery and
+// LayoutOptions are keyed by layout, e.g. "tabular"; set Filter with
+// SetFilter.
+type Preset struct {
+	ID              int             `json:"id,omitempty"`
+	Bookmark        string          `json:"bookmark,omitempty"`
+	User            string          `json:"user,omitempty"`
+	Role            string          `json:"role,omitempty"`
+	Collection      string          `json:"collection,omitempty"`
+	Search          string          `json:"search,omitempty"`
+	Layout          string          `json:"layout,omitempty"`
+	LayoutQuery     json.RawMessage `json:"layout_query,omitempty"`
+	LayoutOptions   json.RawMessage `json:"layout_options,omitempty"`
+	RefreshInterval int             `json:"refresh_interval,omitempty"`
+	Filter          json.RawMessage `json:"filter,omitempty"`
+	Icon            string          `json:"icon,omitempty"`
+	Color           string          `json:"color,omitempty"`
+}
+
+// Presets returns a client for the presets and bookmarks of Directus at
+// /presets.
+func (d *DirectusClient) Presets() *SystemClient[Preset] {
+	return System[Preset](d, "/presets")
+}
+
+// SetFilter sets the filter of p to f.
+func (p *Preset) SetFilter(f FilterNode) error {
+	if f == nil {
+		p.Filter = nil
+		return nil
+	}
+	b, err := currentCodec().Marshal(filterJSON(f))
+	if err != nil {
+		return err
+	}
+	p.Filter = b
+	return nil
+}
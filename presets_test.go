@@ -0,0 +1,45 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresets(t *testing.T) {
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST /presets", r.Method+" "+r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		created["id"] = 7
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"data": created})
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	preset := Preset{
+		Bookmark:    "Published",
+		Role:        "r1",
+		Collection:  "articles",
+		Layout:      "tabular",
+		LayoutQuery: json.RawMessage(`{"tabular":{"sort":["-date_created"]}}`),
+	}
+	require.NoError(t, preset.SetFilter(Filter{"author.name": {OP_eq: "Ann"}}))
+	stored, err := client.Presets().Create(context.Background(), preset)
+	require.NoError(t, err)
+	require.Equal(t, 7, stored.ID)
+	require.Equal(t, map[string]any{
+		"author": map[string]any{"name": map[string]any{"_eq": "Ann"}},
+	}, created["filter"])
+	require.Equal(t, map[string]any{"tabular": map[string]any{"sort": []any{"-date_created"}}}, created["layout_query"])
+	require.JSONEq(t, `{"author":{"name":{"_eq":"Ann"}}}`, string(stored.Filter))
+
+	require.NoError(t, preset.SetFilter(nil))
+	require.Nil(t, preset.Filter)
+}
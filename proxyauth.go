@@ -0,0 +1,74 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+)
+
+// SessionTokenCookie is the default name of the cookie of Directus session
+// logins, see SESSION_COOKIE_NAME.
+const SessionTokenCookie = "directus_session_token"
+
+// ProxyOption configures a handler returned by Proxy.
+type ProxyOption func(*proxyConfig)
+
+type proxyConfig struct {
+	forwardAuth bool
+	policy      *QueryPolicy
+}
+
+// WithForwardedAuth makes the proxy send the credentials of the end user,
+// their Authorization header, access_token query parameter or Directus
+// session cookie, instead of the token of the client, so Directus enforces
+// their permissions. Requests without credentials use the public role. Such
+// requests bypass the query cache and deduplication, whose entries are shared
+// by all users.
+func WithForwardedAuth() ProxyOption {
+	return func(c *proxyConfig) {
+		c.forwardAuth = true
+	}
+}
+
+type forwardedAuthKey struct{}
+
+// forwardedAuth holds the credentials of a proxied request.
+type forwardedAuth struct {
+	authorization string
+	cookie        *http.Cookie
+}
+
+func forwardedAuthFromContext(ctx context.Context) (forwardedAuth, bool) {
+	fa, ok := ctx.Value(forwardedAuthKey{}).(forwardedAuth)
+	return fa, ok
+}
+
+// withForwardedAuth takes the credentials of the end user off r and stores
+// them in its context for CallContext.
+func withForwardedAuth(r *http.Request) *http.Request {
+	var fa forwardedAuth
+	fa.authorization = r.Header.Get("Authorization")
+	if q := r.URL.Query(); q.Has("access_token") {
+		if fa.authorization == "" {
+			fa.authorization = "Bearer " + q.Get("access_token")
+		}
+		q.Del("access_token")
+		r.URL.RawQuery = q.Encode()
+	}
+	if c, err := r.Cookie(SessionTokenCookie); err == nil {
+		fa.cookie = c
+	}
+	r.Header.Del("Cookie")
+	return r.WithContext(context.WithValue(r.Context(), forwardedAuthKey{}, fa))
+}
+
+// setForwardedAuth replaces the credentials of req with fa.
+func setForwardedAuth(req *http.Request, fa forwardedAuth) {
+	req.Header.Del("Authorization")
+	req.Header.Del("Cookie")
+	if fa.authorization != "" {
+		req.Header.Set("Authorization", fa.authorization)
+	}
+	if fa.cookie != nil {
+		req.AddCookie(&http.Cookie{Name: fa.cookie.Name, Value: fa.cookie.Value})
+	}
+}
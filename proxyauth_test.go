@@ -0,0 +1,59 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyForwardedAuth(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.URL.Query().Get("access_token"))
+		got = append(got, r.Header.Get("Authorization")+"|"+r.Header.Get("Cookie"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	store := &memoryCacheService{m: make(map[string][]byte)}
+	cache, err := NewRefreshableQueryCache(store, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "service", cache, WithDeduplication())
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", client.Proxy(1))
+	mux.Handle("/api/", client.Proxy(1, WithForwardedAuth()))
+	serve := func(r *http.Request) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	query := "/items/user?" + mustEncode(t, DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}})
+	r := httptest.NewRequest("GET", "/api"+query, nil)
+	r.Header.Set("Authorization", "Bearer user-a")
+	serve(r)
+	serve(httptest.NewRequest("GET", "/api"+query+"&access_token=user-b", nil))
+	r = httptest.NewRequest("GET", "/api"+query, nil)
+	r.AddCookie(&http.Cookie{Name: SessionTokenCookie, Value: "session-c"})
+	r.AddCookie(&http.Cookie{Name: "app_session", Value: "private"})
+	serve(r)
+	serve(httptest.NewRequest("GET", "/api"+query, nil))
+	require.Empty(t, store.m)
+
+	serve(httptest.NewRequest("GET", "/admin"+query, nil))
+	serve(httptest.NewRequest("GET", "/admin"+query, nil))
+	require.Len(t, store.m, 1)
+
+	require.Equal(t, []string{
+		"Bearer user-a|",
+		"Bearer user-b|",
+		"|directus_session_token=session-c",
+		"|",
+		"Bearer service|",
+	}, got)
+}
@@ -0,0 +1,115 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// queryJSON is the persisted form of a DirectusQuery. Offset and page are
+// only present when set, so a query reads back with the same pagination.
+type queryJSON struct {
+	Fields     Fields          `json:"fields,omitempty"`
+	Filter     json.RawMessage `json:"filter,omitempty"`
+	Sort       Fields          `json:"sort,omitempty"`
+	Limit      int             `json:"limit,omitempty"`
+	Offset     *int            `json:"offset,omitempty"`
+	Page       *int            `json:"page,omitempty"`
+	Cursor     *cursorJSON     `json:"cursor,omitempty"`
+	Meta       *MetaField      `json:"meta,omitempty"`
+	Aggregate  Aggregate       `json:"aggregate,omitempty"`
+	GroupBy    Fields          `json:"groupBy,omitempty"`
+	Alias      Alias           `json:"alias,omitempty"`
+	Export     FileFormat      `json:"export,omitempty"`
+	Version    string          `json:"version,omitempty"`
+	VersionRaw bool            `json:"versionRaw,omitempty"`
+}
+
+type cursorJSON struct {
+	Field string `json:"field"`
+	After any    `json:"after,omitempty"`
+}
+
+// MarshalJSON encodes d for saved searches, audit logs or test fixtures,
+// with the filter in the shape Directus expects. UnmarshalJSON restores it,
+// pagination included; numbers in filters come back as float64.
+func (d DirectusQuery) MarshalJSON() ([]byte, error) {
+	q := queryJSON{
+		Fields:     d.Fields,
+		Sort:       d.Sort,
+		Limit:      d.Limit,
+		Meta:       d.Meta,
+		Aggregate:  d.Aggregate,
+		GroupBy:    d.GroupBy,
+		Alias:      d.Alias,
+		Export:     d.Export,
+		Version:    d.Version,
+		VersionRaw: d.VersionRaw,
+	}
+	if d.Filter != nil {
+		b, err := currentCodec().Marshal(filterJSON(d.Filter))
+		if err != nil {
+			return nil, err
+		}
+		q.Filter = b
+	}
+	if d.offsetIsSet {
+		q.Offset = &d.Offset
+	}
+	if d.pageIsSet {
+		q.Page = &d.Page
+	}
+	if d.cursor != nil {
+		q.Cursor = &cursorJSON{Field: d.cursor.Field, After: d.cursor.After}
+	}
+	return currentCodec().Marshal(q)
+}
+
+func (d *DirectusQuery) UnmarshalJSON(data []byte) error {
+	var q queryJSON
+	if err := currentCodec().Unmarshal(data, &q); err != nil {
+		return err
+	}
+	query := DirectusQuery{
+		Fields:     q.Fields,
+		Sort:       q.Sort,
+		Limit:      q.Limit,
+		Aggregate:  q.Aggregate,
+		GroupBy:    q.GroupBy,
+		Alias:      q.Alias,
+		Export:     q.Export,
+		Version:    q.Version,
+		VersionRaw: q.VersionRaw,
+	}
+	if len(q.Filter) > 0 && string(q.Filter) != "null" {
+		f, err := parseFilter(q.Filter)
+		if err != nil {
+			return err
+		}
+		query.Filter = f
+	}
+	if q.Offset != nil && q.Page != nil {
+		return errors.New("cannot specify both offset and page")
+	}
+	if q.Offset != nil {
+		query.Paginate(OffsetPagination(*q.Offset))
+	}
+	if q.Page != nil {
+		query.Paginate(PagePagination(*q.Page))
+	}
+	if q.Cursor != nil {
+		query.Paginate(CursorPagination(q.Cursor.Field, q.Cursor.After))
+	}
+	if q.Meta != nil {
+		var meta MetaField
+		if err := meta.Unmarshal(string(*q.Meta)); err != nil {
+			return err
+		}
+		query.Meta = &meta
+	}
+	if query.Export != "" && !query.Export.valid() {
+		return errors.New("invalid export format: " + strconv.Quote(string(query.Export)))
+	}
+	*d = query
+	return nil
+}
@@ -0,0 +1,63 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryJSON(t *testing.T) {
+	meta := MetaQueryFilterCount
+	query := DirectusQuery{
+		Fields:    Fields{"id", "title", "author.name"},
+		Filter:    Or{Filter{"author.name": {OP_eq: "a"}}, Filter{"views": {OP_gt: float64(10)}}},
+		Sort:      Fields{"-id"},
+		Limit:     10,
+		Meta:      &meta,
+		Aggregate: Aggregate{AggCount: {"*"}},
+		GroupBy:   Fields{"status"},
+		Alias:     Alias{"writer": "author"},
+		Export:    FileCSV,
+	}
+	query.Paginate(PagePagination(2))
+
+	b, err := json.Marshal(query)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"fields": ["id", "title", "author.name"],
+		"filter": {"_or": [{"author": {"name": {"_eq": "a"}}}, {"views": {"_gt": 10}}]},
+		"sort": ["-id"],
+		"limit": 10,
+		"page": 2,
+		"meta": "filter_count",
+		"aggregate": {"count": ["*"]},
+		"groupBy": ["status"],
+		"alias": {"writer": "author"},
+		"export": "csv"
+	}`, string(b))
+
+	var back DirectusQuery
+	require.NoError(t, json.Unmarshal(b, &back))
+	require.Equal(t, query, back)
+
+	for _, p := range []Pagination{OffsetPagination(0), CursorPagination("-id", float64(5))} {
+		q := DirectusQuery{Filter: Filter{}}
+		q.Paginate(p)
+		b, err := json.Marshal(q)
+		require.NoError(t, err)
+		var back DirectusQuery
+		require.NoError(t, json.Unmarshal(b, &back))
+		require.Equal(t, q, back)
+	}
+
+	for _, bad := range []string{
+		`{"offset": 1, "page": 2}`,
+		`{"meta": "everything"}`,
+		`{"export": "pdf"}`,
+		`{"filter": {"_or": {}}}`,
+	} {
+		var q DirectusQuery
+		require.Error(t, json.Unmarshal([]byte(bad), &q), bad)
+	}
+}
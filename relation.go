@@ -0,0 +1,36 @@
+package directus_client
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Relation is a relational field value. Directus returns the bare primary key
+// unless the related fields are requested (e.g. "author.*"), in which case the
+// related item is inlined; Relation decodes both shapes.
+type Relation[T any] struct {
+	Key  json.RawMessage
+	Item *T
+}
+
+func (r Relation[T]) MarshalJSON() ([]byte, error) {
+	if r.Item != nil {
+		return json.Marshal(r.Item)
+	}
+	if len(r.Key) == 0 {
+		return []byte("null"), nil
+	}
+	return r.Key, nil
+}
+
+func (r *Relation[T]) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		r.Key = nil
+		r.Item = new(T)
+		return json.Unmarshal(trimmed, r.Item)
+	}
+	r.Item = nil
+	r.Key = append(r.Key[:0], trimmed...)
+	return nil
+}
@@ -0,0 +1,24 @@
+package directus_client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelation(t *testing.T) {
+	var items []struct {
+		Author *Relation[user] `json:"author"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`[{"author": 1}, {"author": {"id": 2, "email": "dev@dev.io"}}, {"author": null}]`), &items))
+
+	require.Equal(t, json.RawMessage("1"), items[0].Author.Key)
+	require.Nil(t, items[0].Author.Item)
+	require.Equal(t, &user{2, "dev@dev.io"}, items[1].Author.Item)
+	require.Nil(t, items[2].Author)
+
+	b, err := json.Marshal(items)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"author": 1}, {"author": {"id": 2, "email": "dev@dev.io"}}, {"author": null}]`, string(b))
+}
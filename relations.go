@@ -0,0 +1,143 @@
+package directus_client
+
+import "context"
+
+// CollectionRelation is a relation of the Directus data model. Every
+// relation is a many-to-one from Field of Collection to RelatedCollection;
+// Meta.OneField, if set, is the one-to-many alias field on the other side.
+// Many-to-many relations are two relations of a junction collection, linked
+// by Meta.JunctionField; many-to-any relations have no RelatedCollection but
+// Meta.OneCollectionField and Meta.OneAllowedCollections instead.
+type CollectionRelation struct {
+	Collection        string                    `json:"collection"`
+	Field             string                    `json:"field"`
+	RelatedCollection string                    `json:"related_collection,omitempty"`
+	Meta              *CollectionRelationMeta   `json:"meta,omitempty"`
+	Schema            *CollectionRelationSchema `json:"schema,omitempty"`
+}
+
+// CollectionRelationMeta is how Directus handles a relation.
+// OneDeselectAction is "nullify" or "delete", for the items removed from a
+// one-to-many field.
+type CollectionRelationMeta struct {
+	ID                    int      `json:"id,omitempty"`
+	ManyCollection        string   `json:"many_collection,omitempty"`
+	ManyField             string   `json:"many_field,omitempty"`
+	OneCollection         string   `json:"one_collection,omitempty"`
+	OneField              string   `json:"one_field,omitempty"`
+	OneCollectionField    string   `json:"one_collection_field,omitempty"`
+	OneAllowedCollections []string `json:"one_allowed_collections,omitempty"`
+	JunctionField         string   `json:"junction_field,omitempty"`
+	SortField             string   `json:"sort_field,omitempty"`
+	OneDeselectAction     string   `json:"one_deselect_action,omitempty"`
+}
+
+// CollectionRelationSchema describes the foreign key constraint of a
+// relation. OnUpdate and OnDelete are SQL actions such as "SET NULL" or
+// "CASCADE". Many-to-any relations have no constraint.
+type CollectionRelationSchema struct {
+	Table            string `json:"table,omitempty"`
+	Column           string `json:"column,omitempty"`
+	ForeignKeyTable  string `json:"foreign_key_table,omitempty"`
+	ForeignKeyColumn string `json:"foreign_key_column,omitempty"`
+	ConstraintName   string `json:"constraint_name,omitempty"`
+	OnUpdate         string `json:"on_update,omitempty"`
+	OnDelete         string `json:"on_delete,omitempty"`
+}
+
+// RelationKind is the kind of a relation, see CollectionRelation.Kind.
+type RelationKind string
+
+const (
+	RelationM2O RelationKind = "m2o"
+	RelationO2M RelationKind = "o2m"
+	RelationM2M RelationKind = "m2m"
+	RelationM2A RelationKind = "m2a"
+)
+
+// Kind returns RelationM2A for the relations of many-to-any junctions,
+// RelationM2M for the relations of many-to-many junctions, RelationO2M for
+// other relations with a one-to-many field and RelationM2O for the rest.
+func (r CollectionRelation) Kind() RelationKind {
+	switch {
+	case r.Meta != nil && r.Meta.OneCollectionField != "":
+		return RelationM2A
+	case r.Meta != nil && r.Meta.JunctionField != "":
+		return RelationM2M
+	case r.Meta != nil && r.Meta.OneField != "":
+		return RelationO2M
+	default:
+		return RelationM2O
+	}
+}
+
+// RelationsClient reads and changes the relations of the Directus data model
+// at /relations, which are keyed by the collection and field of their
+// many-to-one side.
+type RelationsClient struct {
+	d *DirectusClient
+}
+
+// Relations returns a client for the relations of the Directus data model.
+func (d *DirectusClient) Relations() *RelationsClient {
+	return &RelationsClient{d: d}
+}
+
+// All returns the relations of all collections.
+func (c *RelationsClient) All(ctx context.Context) ([]CollectionRelation, error) {
+	var relations []CollectionRelation
+	err := c.d.sendJSON(ctx, "GET", "/relations", nil, nil, &relations)
+	return relations, err
+}
+
+// List returns the relations of collection.
+func (c *RelationsClient) List(ctx context.Context, collection string) ([]CollectionRelation, error) {
+	var relations []CollectionRelation
+	path, err := systemPath("/relations", collection)
+	if err != nil {
+		return nil, err
+	}
+	err = c.d.sendJSON(ctx, "GET", path, nil, nil, &relations)
+	return relations, err
+}
+
+// Get returns the relation of field of collection.
+func (c *RelationsClient) Get(ctx context.Context, collection string, field string) (CollectionRelation, error) {
+	var r CollectionRelation
+	path, err := systemPath("/relations", collection, field)
+	if err != nil {
+		return r, err
+	}
+	err = c.d.sendJSON(ctx, "GET", path, nil, nil, &r)
+	return r, err
+}
+
+// Create creates relation, with a foreign key constraint if Schema is set,
+// and returns it as stored by Directus. The fields must exist already.
+func (c *RelationsClient) Create(ctx context.Context, relation CollectionRelation) (CollectionRelation, error) {
+	var created CollectionRelation
+	err := c.d.sendJSON(ctx, "POST", "/relations", nil, relation, &created)
+	return created, err
+}
+
+// Update patches the relation of field of collection and returns it as
+// stored by Directus.
+func (c *RelationsClient) Update(ctx context.Context, collection string, field string, patch any) (CollectionRelation, error) {
+	var updated CollectionRelation
+	path, err := systemPath("/relations", collection, field)
+	if err != nil {
+		return updated, err
+	}
+	err = c.d.sendJSON(ctx, "PATCH", path, nil, patch, &updated)
+	return updated, err
+}
+
+// Delete deletes the relation of field of collection and its constraint,
+// but not the field.
+func (c *RelationsClient) Delete(ctx context.Context, collection string, field string) error {
+	path, err := systemPath("/relations", collection, field)
+	if err != nil {
+		return err
+	}
+	return c.d.sendJSON(ctx, "DELETE", path, nil, nil, nil)
+}
@@ -0,0 +1,94 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelations(t *testing.T) {
+	relations := []map[string]any{
+		{"collection": "articles", "field": "author", "related_collection": "authors",
+			"meta":   map[string]any{"one_field": nil, "junction_field": nil},
+			"schema": map[string]any{"on_delete": "SET NULL"}},
+		{"collection": "comments", "field": "article", "related_collection": "articles",
+			"meta": map[string]any{"one_field": "comments", "one_deselect_action": "delete"}},
+		{"collection": "articles_tags", "field": "tags_id", "related_collection": "tags",
+			"meta": map[string]any{"junction_field": "articles_id"}},
+		{"collection": "pages_blocks", "field": "item", "related_collection": nil,
+			"meta":   map[string]any{"one_collection_field": "collection", "one_allowed_collections": []string{"heroes", "texts"}, "junction_field": "pages_id"},
+			"schema": nil},
+	}
+	var requests []string
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			json.NewEncoder(w).Encode(map[string]any{"data": created})
+		case "GET":
+			if r.URL.Path == "/relations/articles/author" {
+				json.NewEncoder(w).Encode(map[string]any{"data": relations[0]})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": relations})
+		case "PATCH":
+			json.NewEncoder(w).Encode(map[string]any{"data": relations[0]})
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	all, err := client.Relations().All(ctx)
+	require.NoError(t, err)
+	var kinds []RelationKind
+	for _, r := range all {
+		kinds = append(kinds, r.Kind())
+	}
+	require.Equal(t, []RelationKind{RelationM2O, RelationO2M, RelationM2M, RelationM2A}, kinds)
+	require.Equal(t, "", all[3].RelatedCollection)
+	require.Equal(t, []string{"heroes", "texts"}, all[3].Meta.OneAllowedCollections)
+	require.Nil(t, all[3].Schema)
+
+	_, err = client.Relations().Create(ctx, CollectionRelation{
+		Collection:        "articles",
+		Field:             "author",
+		RelatedCollection: "authors",
+		Schema:            &CollectionRelationSchema{OnDelete: "SET NULL"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"collection":         "articles",
+		"field":              "author",
+		"related_collection": "authors",
+		"schema":             map[string]any{"on_delete": "SET NULL"},
+	}, created)
+
+	_, err = client.Relations().List(ctx, "articles")
+	require.NoError(t, err)
+	r, err := client.Relations().Get(ctx, "articles", "author")
+	require.NoError(t, err)
+	require.Equal(t, "SET NULL", r.Schema.OnDelete)
+	_, err = client.Relations().Update(ctx, "articles", "author", map[string]any{"schema": map[string]any{"on_delete": "CASCADE"}})
+	require.NoError(t, err)
+	require.NoError(t, client.Relations().Delete(ctx, "articles", "author"))
+
+	require.Equal(t, []string{
+		"GET /relations",
+		"POST /relations",
+		"GET /relations/articles",
+		"GET /relations/articles/author",
+		"PATCH /relations/articles/author",
+		"DELETE /relations/articles/author",
+	}, requests)
+}
@@ -0,0 +1,64 @@
+package directus_client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader carries the id correlating a request across the proxy, the
+// client logs and Directus. Call keeps an id set by the caller, e.g. the one
+// of a proxied request, and generates one otherwise.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID makes Call use id for requests sent with ctx.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request id stored in ctx. Inside Call,
+// e.g. in middleware and caches, it is always set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestID sets the request id header of req, keeping an existing one,
+// and stores it in the context of the returned request.
+func withRequestID(req *http.Request) *http.Request {
+	ctx := req.Context()
+	id := req.Header.Get(RequestIDHeader)
+	if id == "" {
+		if id = RequestIDFromContext(ctx); id == "" {
+			id = newRequestID()
+		}
+		req.Header.Set(RequestIDHeader, id)
+	}
+	if RequestIDFromContext(ctx) != id {
+		req = req.WithContext(ContextWithRequestID(ctx, id))
+	}
+	return req
+}
+
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// RequestError is returned when a request could not be sent to Directus.
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return "directus request " + e.RequestID + ": " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,56 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	var seen []string
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, RequestIDFromContext(req.Context()))
+			return next(req)
+		}
+	})
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+
+	_, err = client.QueryContext(ContextWithRequestID(context.Background(), "from-context"), "GET", "user", query, nil)
+	require.NoError(t, err)
+	_, err = client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+
+	// proxied requests keep the id of the incoming request and echo it
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/items/user", nil)
+	r.Header.Set(RequestIDHeader, "from-proxy")
+	client.Proxy(0).ServeHTTP(w, r)
+	require.Equal(t, "from-proxy", w.Header().Get(RequestIDHeader))
+
+	require.Len(t, ids, 3)
+	require.Equal(t, "from-context", ids[0])
+	require.Len(t, ids[1], 32)
+	require.Equal(t, "from-proxy", ids[2])
+	require.Equal(t, ids, seen)
+
+	srv.Close()
+	_, err = client.QueryContext(ContextWithRequestID(context.Background(), "failing"), "GET", "user", query, nil)
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, "failing", reqErr.RequestID)
+	require.Contains(t, err.Error(), "directus request failing: ")
+}
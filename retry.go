@@ -0,0 +1,172 @@
+package directus_client
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures WithRetry. Zero fields take their defaults.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, 3 by default.
+	MaxAttempts int
+	// AttemptTimeout bounds each attempt including reading its body; zero
+	// leaves attempts bounded by the client timeout only.
+	AttemptTimeout time.Duration
+	// BaseDelay is doubled after every attempt, 100ms by default.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, 5s by default. Retry-After is honored even
+	// when it is longer, up to MaxRetryAfter.
+	MaxDelay time.Duration
+	// MaxRetryAfter caps the delay requested by Retry-After, 30s by default,
+	// so a server or proxy asking for hours doesn't block callers without a
+	// deadline.
+	MaxRetryAfter time.Duration
+}
+
+func (p *RetryPolicy) applyDefault() {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = 3
+	}
+	if p.BaseDelay == 0 {
+		p.BaseDelay = 100 * time.Millisecond
+	}
+	if p.MaxDelay == 0 {
+		p.MaxDelay = 5 * time.Second
+	}
+	if p.MaxRetryAfter == 0 {
+		p.MaxRetryAfter = 30 * time.Second
+	}
+}
+
+// WithRetry retries idempotent requests (GET, HEAD, SEARCH, OPTIONS, PUT,
+// DELETE) failing with a network error, 429 or a 5xx status, waiting a
+// jittered exponential backoff between attempts, or the Retry-After of the
+// response capped at MaxRetryAfter. SEARCH only reads items, with the query
+// in the body. Requests whose body can't be replayed are sent once.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(d *DirectusClient) {
+		policy.applyDefault()
+		d.retry = &policy
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "SEARCH", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// send is do with the retry policy applied.
+func (d *DirectusClient) send(req *http.Request) (*http.Response, error) {
+	p := d.retry
+	if p == nil || !idempotent(req.Method) || (req.Body != nil && req.GetBody == nil) || primaryOnly(req.Context()) {
+		return d.do(req)
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+		cancel := func() {}
+		if p.AttemptTimeout > 0 {
+			r, cancel = withTimeout(r, p.AttemptTimeout)
+		}
+		resp, err := d.do(r)
+		if attempt == p.MaxAttempts || !retryable(resp, err) || ctx.Err() != nil {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			if p.AttemptTimeout > 0 {
+				resp.Body = &cancelBody{resp.Body, cancel}
+			}
+			return resp, nil
+		}
+
+		delay := p.backoff(attempt)
+		if err == nil {
+			if after := retryAfter(resp.Header.Get("Retry-After")); after > delay {
+				delay = after
+				if delay > p.MaxRetryAfter {
+					delay = p.MaxRetryAfter
+				}
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		cancel()
+		d.log.Debug("retrying directus request", "error", err, "attempt", attempt, "delay", delay, "path", req.URL.Path, "request_id", RequestIDFromContext(ctx))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func withTimeout(r *http.Request, timeout time.Duration) (*http.Request, func()) {
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return r.WithContext(ctx), cancel
+}
+
+// backoff returns a random delay up to BaseDelay * 2^(attempt-1), capped at
+// MaxDelay ("full jitter").
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MaxDelay
+	if attempt < 32 {
+		if exp := p.BaseDelay << (attempt - 1); exp > 0 && exp < d {
+			d = exp
+		}
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// retryAfter parses delay-seconds and HTTP-date values.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// cancelBody releases the context of an attempt once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
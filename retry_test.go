@@ -0,0 +1,100 @@
+package directus_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt64(&hits, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithRetry(RetryPolicy{BaseDelay: time.Millisecond}))
+	require.NoError(t, err)
+
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+	start := time.Now()
+	resp, err := client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	result := ReadResult[[]user](resp)
+	require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	require.EqualValues(t, 3, atomic.LoadInt64(&hits))
+	require.GreaterOrEqual(t, time.Since(start), time.Second, "Retry-After is honored")
+
+	// POST is not idempotent
+	atomic.StoreInt64(&hits, 0)
+	resp, err = client.Query("POST", "user", query, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.EqualValues(t, 1, atomic.LoadInt64(&hits))
+}
+
+func TestRetryAfterCapped(t *testing.T) {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithRetry(RetryPolicy{BaseDelay: time.Millisecond, MaxRetryAfter: 10 * time.Millisecond}))
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 2, atomic.LoadInt64(&hits))
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithRetry(RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, AttemptTimeout: time.Second}))
+	require.NoError(t, err)
+
+	resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.EqualValues(t, 4, atomic.LoadInt64(&hits))
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{}
+	p.applyDefault()
+	for attempt := 1; attempt < 100; attempt++ {
+		d := p.backoff(attempt)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.LessOrEqual(t, d, p.MaxDelay)
+	}
+	require.Equal(t, 2*time.Second, retryAfter("2"))
+	require.Zero(t, retryAfter("soon"))
+}
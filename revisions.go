@@ -0,0 +1,92 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Revision is a change of an item recorded by Directus. Data is the item
+// after the change and Delta the fields the change wrote; decode them into
+// the type of the collection with DecodeData and DecodeDelta. Activity is
+// the id of the activity of the change and Version the id of the content
+// version it was made in, if any.
+type Revision struct {
+	ID         int             `json:"id,omitempty"`
+	Activity   int             `json:"activity,omitempty"`
+	Collection string          `json:"collection,omitempty"`
+	Item       string          `json:"item,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Delta      json.RawMessage `json:"delta,omitempty"`
+	Parent     int             `json:"parent,omitempty"`
+	Version    string          `json:"version,omitempty"`
+}
+
+// DecodeData decodes the item after the change into v.
+func (r Revision) DecodeData(v any) error {
+	if len(r.Data) == 0 {
+		return errors.New("directus: revision has no data")
+	}
+	return currentCodec().Unmarshal(r.Data, v)
+}
+
+// DecodeDelta decodes the fields the change wrote into v, which is best a
+// type with pointer or omitempty fields; see ChangedFields for their names.
+func (r Revision) DecodeDelta(v any) error {
+	if len(r.Delta) == 0 {
+		return errors.New("directus: revision has no delta")
+	}
+	return currentCodec().Unmarshal(r.Delta, v)
+}
+
+// ChangedFields returns the sorted names of the fields the change wrote.
+func (r Revision) ChangedFields() ([]string, error) {
+	var delta map[string]json.RawMessage
+	if len(r.Delta) > 0 {
+		if err := currentCodec().Unmarshal(r.Delta, &delta); err != nil {
+			return nil, err
+		}
+	}
+	fields := make([]string, 0, len(delta))
+	for f := range delta {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// RevisionsClient reads the revisions of Directus at /revisions, which are
+// read-only.
+type RevisionsClient struct {
+	*SystemClient[Revision]
+}
+
+// Revisions returns a client for the revisions of Directus.
+func (d *DirectusClient) Revisions() *RevisionsClient {
+	return &RevisionsClient{System[Revision](d, "/revisions")}
+}
+
+// Revert restores the item of the revision with id to its state after that
+// revision by writing all fields of its data, which Directus records as a
+// new revision. Items of user collections are written through the cache and
+// the middleware like ItemsClient.Update.
+func (c *RevisionsClient) Revert(ctx context.Context, id int) error {
+	r, err := c.Get(ctx, id, "collection", "item", "data")
+	if err != nil {
+		return err
+	}
+	if r.Collection == "" || len(r.Data) == 0 || string(r.Data) == "null" {
+		return errors.New("directus: revision has no data to revert to")
+	}
+	if system := strings.TrimPrefix(r.Collection, "directus_"); system != r.Collection {
+		path, err := systemPath("/"+system, r.Item)
+		if err != nil {
+			return err
+		}
+		return c.d.sendJSON(ctx, "PATCH", path, nil, r.Data, nil)
+	}
+	_, err = Items[json.RawMessage](c.d, r.Collection).Update(ctx, r.Item, r.Data)
+	return err
+}
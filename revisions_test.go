@@ -0,0 +1,72 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisions(t *testing.T) {
+	revisions := map[string]map[string]any{
+		"1": {"id": 1, "activity": 10, "collection": "articles", "item": "5",
+			"data":  map[string]any{"id": 5, "title": "Hello", "status": "draft"},
+			"delta": map[string]any{"title": "Hello", "status": "draft"}},
+		"2": {"id": 2, "activity": 11, "collection": "directus_users", "item": "u1",
+			"data": map[string]any{"id": "u1", "first_name": "Ann"}},
+	}
+	var patches []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/revisions":
+			require.Equal(t, `{"collection":{"_eq":"articles"}}`, r.URL.Query().Get("filter"))
+			json.NewEncoder(w).Encode(map[string]any{"data": []any{revisions["1"]}})
+		case r.Method == "GET":
+			require.Equal(t, "collection,item,data", r.URL.Query().Get("fields"))
+			json.NewEncoder(w).Encode(map[string]any{"data": revisions[r.URL.Path[len("/revisions/"):]]})
+		case r.Method == "PATCH":
+			b, _ := io.ReadAll(r.Body)
+			patches = append(patches, r.URL.Path+" "+string(b))
+			json.NewEncoder(w).Encode(map[string]any{"data": json.RawMessage(b)})
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	list, _, err := client.Revisions().List(ctx, DirectusQuery{Filter: Filter{"collection": {OP_eq: "articles"}}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	rev := list[0]
+	require.Equal(t, 10, rev.Activity)
+	changed, err := rev.ChangedFields()
+	require.NoError(t, err)
+	require.Equal(t, []string{"status", "title"}, changed)
+	var delta struct {
+		Title  *string `json:"title"`
+		Status *string `json:"status"`
+		Body   *string `json:"body"`
+	}
+	require.NoError(t, rev.DecodeDelta(&delta))
+	require.Equal(t, "Hello", *delta.Title)
+	require.Nil(t, delta.Body)
+	var data article
+	require.NoError(t, rev.DecodeData(&data))
+	require.Equal(t, article{ID: 5, Title: "Hello", Status: "draft"}, data)
+
+	require.NoError(t, client.Revisions().Revert(ctx, 1))
+	require.NoError(t, client.Revisions().Revert(ctx, 2))
+	require.Equal(t, []string{
+		`/items/articles/5 {"id":5,"status":"draft","title":"Hello"}`,
+		`/users/u1 {"first_name":"Ann","id":"u1"}`,
+	}, patches)
+
+	revisions["3"] = map[string]any{"id": 3, "collection": "articles", "item": "5", "data": nil}
+	require.Error(t, client.Revisions().Revert(ctx, 3))
+}
@@ -0,0 +1,22 @@
+package directus_client
+
+// Role is a Directus role. Users lists the ids of its users. AdminAccess and
+// AppAccess are properties of roles up to Directus 10; from Directus 11 on
+// they belong to the access policies of the role.
+type Role struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Description string   `json:"description,omitempty"`
+	IPAccess    []string `json:"ip_access,omitempty"`
+	EnforceTFA  bool     `json:"enforce_tfa,omitempty"`
+	AdminAccess bool     `json:"admin_access,omitempty"`
+	AppAccess   bool     `json:"app_access,omitempty"`
+	Parent      string   `json:"parent,omitempty"`
+	Users       []string `json:"users,omitempty"`
+}
+
+// Roles returns a client for the roles of Directus at /roles.
+func (d *DirectusClient) Roles() *SystemClient[Role] {
+	return System[Role](d, "/roles")
+}
@@ -0,0 +1,58 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoles(t *testing.T) {
+	roles := map[string]map[string]any{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /roles":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			body["id"] = "r1"
+			roles["r1"] = body
+			json.NewEncoder(w).Encode(map[string]any{"data": body})
+		case "GET /roles":
+			list := []map[string]any{}
+			for _, role := range roles {
+				list = append(list, role)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": list})
+		case "PATCH /roles/r1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&map[string]any{}))
+			roles["r1"]["users"] = []string{"u1"}
+			json.NewEncoder(w).Encode(map[string]any{"data": roles["r1"]})
+		case "DELETE /roles/r1":
+			delete(roles, "r1")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	created, err := client.Roles().Create(ctx, Role{Name: "Editors", AppAccess: true, IPAccess: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+	require.Equal(t, Role{ID: "r1", Name: "Editors", AppAccess: true, IPAccess: []string{"10.0.0.1"}}, created)
+	require.Equal(t, map[string]any{"id": "r1", "name": "Editors", "app_access": true, "ip_access": []any{"10.0.0.1"}}, roles["r1"])
+
+	updated, err := client.Roles().Update(ctx, "r1", map[string]any{"users": []string{"u1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1"}, updated.Users)
+
+	list, _, err := client.Roles().List(ctx, DirectusQuery{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.NoError(t, client.Roles().Delete(ctx, "r1"))
+	require.Empty(t, roles)
+}
@@ -0,0 +1,136 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// CollectionField is a field of a collection of the Directus data model.
+// Type is the Directus type, e.g. "string", "integer", "uuid" or "alias".
+// Schema is nil for alias fields, which have no database column, such as
+// O2M relations and presentation fields.
+type CollectionField struct {
+	Collection string                 `json:"collection,omitempty"`
+	Field      string                 `json:"field"`
+	Type       string                 `json:"type,omitempty"`
+	Meta       *CollectionFieldMeta   `json:"meta,omitempty"`
+	Schema     *CollectionFieldSchema `json:"schema,omitempty"`
+}
+
+// CollectionFieldMeta is how Directus presents and handles a field. Special
+// lists its transformations, e.g. "uuid", "date-created" or "m2o".
+type CollectionFieldMeta struct {
+	ID                int             `json:"id,omitempty"`
+	Special           []string        `json:"special,omitempty"`
+	Interface         string          `json:"interface,omitempty"`
+	Options           json.RawMessage `json:"options,omitempty"`
+	Display           string          `json:"display,omitempty"`
+	DisplayOptions    json.RawMessage `json:"display_options,omitempty"`
+	Readonly          bool            `json:"readonly,omitempty"`
+	Hidden            bool            `json:"hidden,omitempty"`
+	Required          bool            `json:"required,omitempty"`
+	Sort              int             `json:"sort,omitempty"`
+	Width             string          `json:"width,omitempty"`
+	Group             string          `json:"group,omitempty"`
+	Note              string          `json:"note,omitempty"`
+	Translations      json.RawMessage `json:"translations,omitempty"`
+	Conditions        json.RawMessage `json:"conditions,omitempty"`
+	Validation        json.RawMessage `json:"validation,omitempty"`
+	ValidationMessage string          `json:"validation_message,omitempty"`
+}
+
+// CollectionFieldSchema describes the database column of a field.
+// IsNullable is a pointer since Directus makes new columns nullable unless
+// told otherwise; nil lengths and precisions don't apply to the column.
+type CollectionFieldSchema struct {
+	Name                 string `json:"name,omitempty"`
+	Table                string `json:"table,omitempty"`
+	DataType             string `json:"data_type,omitempty"`
+	DefaultValue         any    `json:"default_value,omitempty"`
+	MaxLength            *int   `json:"max_length,omitempty"`
+	NumericPrecision     *int   `json:"numeric_precision,omitempty"`
+	NumericScale         *int   `json:"numeric_scale,omitempty"`
+	IsNullable           *bool  `json:"is_nullable,omitempty"`
+	IsUnique             bool   `json:"is_unique,omitempty"`
+	IsPrimaryKey         bool   `json:"is_primary_key,omitempty"`
+	IsGenerated          bool   `json:"is_generated,omitempty"`
+	GenerationExpression string `json:"generation_expression,omitempty"`
+	HasAutoIncrement     bool   `json:"has_auto_increment,omitempty"`
+	ForeignKeyTable      string `json:"foreign_key_table,omitempty"`
+	ForeignKeyColumn     string `json:"foreign_key_column,omitempty"`
+	Comment              string `json:"comment,omitempty"`
+}
+
+// FieldsClient reads and changes the fields of the Directus data model at
+// /fields, which are keyed by collection and field name.
+type FieldsClient struct {
+	d *DirectusClient
+}
+
+// Fields returns a client for the fields of the Directus data model.
+func (d *DirectusClient) Fields() *FieldsClient {
+	return &FieldsClient{d: d}
+}
+
+// All returns the fields of all collections.
+func (c *FieldsClient) All(ctx context.Context) ([]CollectionField, error) {
+	var fields []CollectionField
+	err := c.d.sendJSON(ctx, "GET", "/fields", nil, nil, &fields)
+	return fields, err
+}
+
+// List returns the fields of collection.
+func (c *FieldsClient) List(ctx context.Context, collection string) ([]CollectionField, error) {
+	var fields []CollectionField
+	path, err := systemPath("/fields", collection)
+	if err != nil {
+		return nil, err
+	}
+	err = c.d.sendJSON(ctx, "GET", path, nil, nil, &fields)
+	return fields, err
+}
+
+// Get returns the field of collection named field.
+func (c *FieldsClient) Get(ctx context.Context, collection string, field string) (CollectionField, error) {
+	var f CollectionField
+	path, err := systemPath("/fields", collection, field)
+	if err != nil {
+		return f, err
+	}
+	err = c.d.sendJSON(ctx, "GET", path, nil, nil, &f)
+	return f, err
+}
+
+// Create adds field to collection, with a database column unless it is an
+// alias field, and returns it as stored by Directus.
+func (c *FieldsClient) Create(ctx context.Context, collection string, field CollectionField) (CollectionField, error) {
+	var created CollectionField
+	path, err := systemPath("/fields", collection)
+	if err != nil {
+		return created, err
+	}
+	err = c.d.sendJSON(ctx, "POST", path, nil, field, &created)
+	return created, err
+}
+
+// Update patches the field of collection named field and returns it as
+// stored by Directus. Changing the type or schema alters the column.
+func (c *FieldsClient) Update(ctx context.Context, collection string, field string, patch any) (CollectionField, error) {
+	var updated CollectionField
+	path, err := systemPath("/fields", collection, field)
+	if err != nil {
+		return updated, err
+	}
+	err = c.d.sendJSON(ctx, "PATCH", path, nil, patch, &updated)
+	return updated, err
+}
+
+// Delete deletes the field of collection named field, dropping its column
+// and values.
+func (c *FieldsClient) Delete(ctx context.Context, collection string, field string) error {
+	path, err := systemPath("/fields", collection, field)
+	if err != nil {
+		return err
+	}
+	return c.d.sendJSON(ctx, "DELETE", path, nil, nil, nil)
+}
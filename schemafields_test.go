@@ -0,0 +1,90 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsClient(t *testing.T) {
+	var requests []string
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		title := map[string]any{
+			"collection": "articles", "field": "title", "type": "string",
+			"meta":   map[string]any{"interface": "input", "options": map[string]any{"trim": true}, "special": nil},
+			"schema": map[string]any{"data_type": "character varying", "max_length": 255, "is_nullable": false, "default_value": nil},
+		}
+		switch r.Method {
+		case "POST":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			json.NewEncoder(w).Encode(map[string]any{"data": title})
+		case "GET":
+			if r.URL.Path == "/fields/articles/title" {
+				json.NewEncoder(w).Encode(map[string]any{"data": title})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": []any{title}})
+		case "PATCH":
+			json.NewEncoder(w).Encode(map[string]any{"data": title})
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	notNull := false
+	maxLength := 255
+	field, err := client.Fields().Create(ctx, "articles", CollectionField{
+		Field:  "title",
+		Type:   "string",
+		Meta:   &CollectionFieldMeta{Interface: "input"},
+		Schema: &CollectionFieldSchema{MaxLength: &maxLength, IsNullable: &notNull},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"field":  "title",
+		"type":   "string",
+		"meta":   map[string]any{"interface": "input"},
+		"schema": map[string]any{"max_length": float64(255), "is_nullable": false},
+	}, created)
+	require.Equal(t, CollectionField{
+		Collection: "articles",
+		Field:      "title",
+		Type:       "string",
+		Meta:       &CollectionFieldMeta{Interface: "input", Options: json.RawMessage(`{"trim":true}`)},
+		Schema:     &CollectionFieldSchema{DataType: "character varying", MaxLength: &maxLength, IsNullable: &notNull},
+	}, field)
+
+	all, err := client.Fields().All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	list, err := client.Fields().List(ctx, "articles")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	_, err = client.Fields().Get(ctx, "articles", "title")
+	require.NoError(t, err)
+	_, err = client.Fields().Update(ctx, "articles", "title", CollectionField{Field: "title", Meta: &CollectionFieldMeta{Note: "Headline"}})
+	require.NoError(t, err)
+	require.NoError(t, client.Fields().Delete(ctx, "articles", "title"))
+	_, err = client.Fields().Get(ctx, "articles", "")
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		"POST /fields/articles",
+		"GET /fields",
+		"GET /fields/articles",
+		"GET /fields/articles/title",
+		"PATCH /fields/articles/title",
+		"DELETE /fields/articles/title",
+	}, requests)
+}
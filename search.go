@@ -0,0 +1,82 @@
+package directus_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// searchBody turns query parameters built by BuildQuery into the body of a
+// SEARCH request, which Directus expects as {"query": {...}}.
+func searchBody(v url.Values) ([]byte, error) {
+	q := make(map[string]any, len(v))
+	if hasBracketFilter(v) {
+		filter, err := unbracketFilter(v)
+		if err != nil {
+			return nil, err
+		}
+		q["filter"] = json.RawMessage(filter)
+	}
+	for k := range v {
+		s := v.Get(k)
+		if strings.HasPrefix(k, "filter[") {
+			continue
+		}
+		switch k {
+		case "fields", "sort", "groupBy":
+			q[k] = strings.Split(s, ",")
+		case "limit", "offset", "page":
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, err
+			}
+			q[k] = n
+		case "filter", "deep", "alias", "aggregate":
+			q[k] = json.RawMessage(s)
+		default:
+			q[k] = s
+		}
+	}
+	return currentCodec().Marshal(map[string]any{"query": q})
+}
+
+// DefaultSearchThreshold is the query length above which WithSearchFallback
+// switches to SEARCH unless told otherwise. It stays well below the 4 to 8 KiB
+// request line limits common to reverse proxies.
+const DefaultSearchThreshold = 2048
+
+// WithSearchFallback sends GET item queries with a query string longer than
+// maxQueryLength bytes, e.g. large _in filters, as SEARCH requests with the
+// query in the body. Responses are cached under the original query. A
+// maxQueryLength of 0 uses DefaultSearchThreshold.
+func WithSearchFallback(maxQueryLength int) ClientOption {
+	return func(d *DirectusClient) {
+		if maxQueryLength <= 0 {
+			maxQueryLength = DefaultSearchThreshold
+		}
+		d.searchThreshold = maxQueryLength
+	}
+}
+
+// asSearch returns a copy of the GET request req sending its query as SEARCH
+// body.
+func asSearch(req *http.Request) (*http.Request, error) {
+	body, err := searchBody(req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.Method = "SEARCH"
+	r.URL.RawQuery = ""
+	r.Header.Set("Content-Type", "application/json")
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.ContentLength = int64(len(body))
+	return r, nil
+}
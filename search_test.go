@@ -0,0 +1,101 @@
+package directus_client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchAndHead(t *testing.T) {
+	var upstream int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		switch r.Method {
+		case "HEAD":
+			w.Header().Set("Content-Type", "application/json")
+		case "SEARCH":
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"query":{"fields":["id","email"],"filter":{"id":{"_in":[1,2]}},"limit":1000}}`, string(body))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+	store := &memoryCacheService{m: make(map[string][]byte)}
+	cache, err := NewRefreshableQueryCache(store, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+
+	query := DirectusQuery{Fields: Fields{"id", "email"}, Filter: Filter{"id": {OP_in: []int{1, 2}}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("SEARCH", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+
+		resp, err = client.Query("HEAD", "user/1", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 4, atomic.LoadInt32(&upstream))
+	require.Empty(t, store.m)
+
+	// explicit bodies are sent as is
+	resp, err := client.Query("SEARCH", "user", query, strings.NewReader(`{"query":{"fields":["id","email"],"filter":{"id":{"_in":[1,2]}},"limit":1000}}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	_, err = client.Query("PUT", "user", query, nil)
+	require.EqualError(t, err, "invalid method")
+}
+
+func TestSearchFallback(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		require.Empty(t, r.URL.RawQuery)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `"_in":[0,1,2,`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":1,"email":"dev@dev.io"}]}`))
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache, WithSearchFallback(0))
+	require.NoError(t, err)
+
+	ids := make([]int, 1000)
+	for i := range ids {
+		ids[i] = i
+	}
+	query := DirectusQuery{Filter: Filter{"id": {OP_in: ids}}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query("GET", "user", query, nil)
+		require.NoError(t, err)
+		result := ReadResult[[]user](resp)
+		require.False(t, result.Err(), result.Errors)
+		require.Equal(t, []user{{1, "dev@dev.io"}}, result.Data)
+	}
+	require.Equal(t, []string{"SEARCH"}, methods)
+
+	// cached under the GET query
+	data, err := cache.Get("user", mustEncode(t, query))
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+}
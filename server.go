@@ -0,0 +1,224 @@
+package directus_client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type HealthStatus string
+
+const (
+	HealthOK    HealthStatus = "ok"
+	HealthWarn  HealthStatus = "warn"
+	HealthError HealthStatus = "error"
+)
+
+// Health is the response of /server/health. Directus only reports checks to
+// admin tokens.
+type Health struct {
+	Status    HealthStatus             `json:"status"`
+	ReleaseID string                   `json:"releaseId,omitempty"`
+	ServiceID string                   `json:"serviceId,omitempty"`
+	Checks    map[string][]HealthCheck `json:"checks,omitempty"`
+}
+
+type HealthCheck struct {
+	ComponentType string       `json:"componentType,omitempty"`
+	ObservedValue float64      `json:"observedValue,omitempty"`
+	ObservedUnit  string       `json:"observedUnit,omitempty"`
+	Status        HealthStatus `json:"status"`
+	Threshold     float64      `json:"threshold,omitempty"`
+	Output        any          `json:"output,omitempty"`
+}
+
+// Healthy reports whether Directus can serve requests; warnings count as
+// healthy.
+func (h *Health) Healthy() bool {
+	return h.Status == HealthOK || h.Status == HealthWarn
+}
+
+// Health fetches /server/health of the base URL. Directus answers 503 with
+// the failing checks when unhealthy; these are returned together with an
+// error. The check is sent once, without retries or failover, so it reports
+// the state of the base URL only.
+func (d *DirectusClient) Health(ctx context.Context) (*Health, error) {
+	resp, err := d.callSystem(withPrimaryOnly(ctx), "GET", "/server/health", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, responseError(resp, resp.Body)
+	}
+	var h Health
+	if err := decodeJSON(resp.Body, &h); err != nil {
+		return nil, err
+	}
+	if !h.Healthy() {
+		return &h, fmt.Errorf("directus: unhealthy: %s", h.Status)
+	}
+	return &h, nil
+}
+
+// Ping calls /server/ping and returns the round trip time.
+func (d *DirectusClient) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	resp, err := d.callSystem(ctx, "GET", "/server/ping", nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, responseError(resp, resp.Body)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimSpace(string(body)) != "pong" {
+		return 0, fmt.Errorf("directus: unexpected ping response %q", body)
+	}
+	return time.Since(start), nil
+}
+
+// ServerInfo is the data of /server/info. Directus omits the version and
+// most settings for tokens without admin access.
+type ServerInfo struct {
+	Project    ProjectInfo     `json:"project"`
+	Directus   *DirectusInfo   `json:"directus,omitempty"`
+	RateLimit  *RateLimitInfo  `json:"rateLimit,omitempty"`
+	QueryLimit *QueryLimitInfo `json:"queryLimit,omitempty"`
+	Websocket  *WebsocketInfo  `json:"websocket,omitempty"`
+}
+
+type ProjectInfo struct {
+	ProjectName       string `json:"project_name"`
+	ProjectDescriptor string `json:"project_descriptor,omitempty"`
+	ProjectLogo       string `json:"project_logo,omitempty"`
+	ProjectColor      string `json:"project_color,omitempty"`
+	DefaultLanguage   string `json:"default_language,omitempty"`
+	PublicForeground  string `json:"public_foreground,omitempty"`
+	PublicBackground  string `json:"public_background,omitempty"`
+	PublicNote        string `json:"public_note,omitempty"`
+	CustomCSS         string `json:"custom_css,omitempty"`
+}
+
+type DirectusInfo struct {
+	Version string `json:"version"`
+}
+
+// RateLimitInfo is reported as false by Directus when rate limiting is off.
+type RateLimitInfo struct {
+	Enabled  bool `json:"-"`
+	Points   int  `json:"points"`
+	Duration int  `json:"duration"`
+}
+
+func (r *RateLimitInfo) UnmarshalJSON(data []byte) error {
+	type plain RateLimitInfo
+	return unmarshalFlag(data, &r.Enabled, (*plain)(r))
+}
+
+type QueryLimitInfo struct {
+	Default int `json:"default"`
+	Max     int `json:"max"`
+}
+
+// WebsocketInfo is reported as false by Directus when websockets are off.
+type WebsocketInfo struct {
+	Enabled   bool           `json:"-"`
+	REST      *WebsocketPath `json:"rest,omitempty"`
+	GraphQL   *WebsocketPath `json:"graphql,omitempty"`
+	Heartbeat int            `json:"heartbeat,omitempty"`
+}
+
+func (w *WebsocketInfo) UnmarshalJSON(data []byte) error {
+	type plain WebsocketInfo
+	return unmarshalFlag(data, &w.Enabled, (*plain)(w))
+}
+
+// WebsocketPath is reported as false by Directus when the endpoint is off.
+type WebsocketPath struct {
+	Enabled        bool   `json:"-"`
+	Authentication string `json:"authentication"`
+	Path           string `json:"path"`
+}
+
+func (w *WebsocketPath) UnmarshalJSON(data []byte) error {
+	type plain WebsocketPath
+	return unmarshalFlag(data, &w.Enabled, (*plain)(w))
+}
+
+// unmarshalFlag decodes settings Directus reports either as false or as an
+// object when enabled.
+func unmarshalFlag(data []byte, enabled *bool, v any) error {
+	switch strings.TrimSpace(string(data)) {
+	case "false", "null":
+		*enabled = false
+		return nil
+	}
+	*enabled = true
+	return currentCodec().Unmarshal(data, v)
+}
+
+// ServerInfo fetches /server/info.
+func (d *DirectusClient) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	resp, err := d.callSystem(ctx, "GET", "/server/info", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var info ServerInfo
+	if err := readData(resp, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// VersionAtLeast reports whether Directus is at least version, e.g. "10.2".
+// It is false when the version is unknown, i.e. for non admin tokens.
+func (i *ServerInfo) VersionAtLeast(version string) bool {
+	if i.Directus == nil || i.Directus.Version == "" {
+		return false
+	}
+	return compareVersions(i.Directus.Version, version) >= 0
+}
+
+// compareVersions compares dotted numeric versions, ignoring pre-release
+// suffixes.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,108 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthAndPing(t *testing.T) {
+	var healthy int32 = 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NotEmpty(t, r.Header.Get(RequestIDHeader))
+		switch r.URL.Path {
+		case "/server/ping":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("pong"))
+		case "/server/health":
+			w.Header().Set("Content-Type", "application/health+json")
+			if atomic.LoadInt32(&healthy) == 1 {
+				w.Write([]byte(`{"status":"ok","releaseId":"10.0.0","serviceId":"3292c816","checks":{"pg:responseTime":[{"status":"ok","componentType":"datastore","observedUnit":"ms","observedValue":0.48,"threshold":150}]}}`))
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"error","releaseId":"10.0.0","serviceId":"3292c816","checks":{"pg:connectionsAvailable":[{"status":"error","componentType":"datastore","output":"connection refused"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	rtt, err := client.Ping(context.Background())
+	require.NoError(t, err)
+	require.Greater(t, rtt, time.Duration(0))
+
+	h, err := client.Health(context.Background())
+	require.NoError(t, err)
+	require.True(t, h.Healthy())
+	require.Equal(t, "10.0.0", h.ReleaseID)
+	require.Equal(t, []HealthCheck{{ComponentType: "datastore", ObservedValue: 0.48, ObservedUnit: "ms", Status: HealthOK, Threshold: 150}}, h.Checks["pg:responseTime"])
+
+	atomic.StoreInt32(&healthy, 0)
+	h, err = client.Health(context.Background())
+	require.Error(t, err)
+	require.False(t, h.Healthy())
+	require.Equal(t, "connection refused", h.Checks["pg:connectionsAvailable"][0].Output)
+}
+
+func TestHealthNoFailover(t *testing.T) {
+	var primaryHits, standbyHits int64
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&primaryHits, 1)
+		w.Header().Set("Content-Type", "application/health+json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"status":"error"}`))
+	}))
+	defer primary.Close()
+	standby := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&standbyHits, 1)
+		w.Header().Set("Content-Type", "application/health+json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer standby.Close()
+	client, err := NewDirectusClient(primary.URL, "token", NewNoopQueryCache(),
+		WithFailover(standby.URL), WithRetry(RetryPolicy{BaseDelay: time.Millisecond}))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		h, err := client.Health(context.Background())
+		require.Error(t, err)
+		require.Equal(t, HealthError, h.Status)
+	}
+	require.EqualValues(t, 2, atomic.LoadInt64(&primaryHits))
+	require.Zero(t, atomic.LoadInt64(&standbyHits))
+}
+
+func TestServerInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/server/info", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"project":{"project_name":"Enku","default_language":"en-US"},"directus":{"version":"10.2.1"},"rateLimit":false,"queryLimit":{"default":100,"max":-1},"websocket":{"rest":{"authentication":"handshake","path":"/websocket"},"graphql":false,"heartbeat":30}}}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	info, err := client.ServerInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Enku", info.Project.ProjectName)
+	require.Equal(t, "10.2.1", info.Directus.Version)
+	require.False(t, info.RateLimit.Enabled)
+	require.Equal(t, &QueryLimitInfo{Default: 100, Max: -1}, info.QueryLimit)
+	require.True(t, info.Websocket.Enabled)
+	require.Equal(t, "/websocket", info.Websocket.REST.Path)
+	require.False(t, info.Websocket.GraphQL.Enabled)
+
+	require.True(t, info.VersionAtLeast("10.2"))
+	require.True(t, info.VersionAtLeast("9.26.0"))
+	require.False(t, info.VersionAtLeast("10.10.0"))
+	require.False(t, (&ServerInfo{}).VersionAtLeast("9"))
+}
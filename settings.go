@@ -0,0 +1,80 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ProjectSettings are the settings of a Directus project: its branding,
+// security, asset and module configuration. ModuleBar, Basemaps and the
+// theme overrides are passed on as they are.
+type ProjectSettings struct {
+	ID                    int             `json:"id,omitempty"`
+	ProjectName           string          `json:"project_name,omitempty"`
+	ProjectDescriptor     string          `json:"project_descriptor,omitempty"`
+	ProjectURL            string          `json:"project_url,omitempty"`
+	ProjectColor          string          `json:"project_color,omitempty"`
+	ProjectLogo           string          `json:"project_logo,omitempty"`
+	PublicForeground      string          `json:"public_foreground,omitempty"`
+	PublicBackground      string          `json:"public_background,omitempty"`
+	PublicFavicon         string          `json:"public_favicon,omitempty"`
+	PublicNote            string          `json:"public_note,omitempty"`
+	DefaultLanguage       string          `json:"default_language,omitempty"`
+	DefaultAppearance     string          `json:"default_appearance,omitempty"`
+	DefaultThemeLight     string          `json:"default_theme_light,omitempty"`
+	DefaultThemeDark      string          `json:"default_theme_dark,omitempty"`
+	ThemeLightOverrides   json.RawMessage `json:"theme_light_overrides,omitempty"`
+	ThemeDarkOverrides    json.RawMessage `json:"theme_dark_overrides,omitempty"`
+	CustomCSS             string          `json:"custom_css,omitempty"`
+	AuthLoginAttempts     int             `json:"auth_login_attempts,omitempty"`
+	AuthPasswordPolicy    string          `json:"auth_password_policy,omitempty"`
+	StorageAssetTransform string          `json:"storage_asset_transform,omitempty"`
+	StorageAssetPresets   []AssetPreset   `json:"storage_asset_presets,omitempty"`
+	StorageDefaultFolder  string          `json:"storage_default_folder,omitempty"`
+	ModuleBar             json.RawMessage `json:"module_bar,omitempty"`
+	Basemaps              json.RawMessage `json:"basemaps,omitempty"`
+	MapboxKey             string          `json:"mapbox_key,omitempty"`
+	CustomAspectRatios    json.RawMessage `json:"custom_aspect_ratios,omitempty"`
+	ReportErrorURL        string          `json:"report_error_url,omitempty"`
+	ReportBugURL          string          `json:"report_bug_url,omitempty"`
+	ReportFeatureURL      string          `json:"report_feature_url,omitempty"`
+}
+
+// AssetPreset is a transformation of assets requested by Key, see
+// AssetOptions. Transforms are further sharp operations.
+type AssetPreset struct {
+	Key                string          `json:"key"`
+	Fit                AssetFit        `json:"fit,omitempty"`
+	Width              int             `json:"width,omitempty"`
+	Height             int             `json:"height,omitempty"`
+	Quality            int             `json:"quality,omitempty"`
+	WithoutEnlargement bool            `json:"withoutEnlargement,omitempty"`
+	Format             AssetFormat     `json:"format,omitempty"`
+	Transforms         json.RawMessage `json:"transforms,omitempty"`
+}
+
+// SettingsClient reads and changes the project settings at /settings.
+type SettingsClient struct {
+	d *DirectusClient
+}
+
+// Settings returns a client for the project settings of Directus.
+func (d *DirectusClient) Settings() *SettingsClient {
+	return &SettingsClient{d: d}
+}
+
+// Get returns the project settings, reduced to fields if given.
+func (c *SettingsClient) Get(ctx context.Context, fields ...string) (ProjectSettings, error) {
+	var s ProjectSettings
+	err := c.d.sendJSON(ctx, "GET", "/settings", fieldsQuery(fields), nil, &s)
+	return s, err
+}
+
+// Update patches the project settings and returns them as stored by
+// Directus. A ProjectSettings patch only writes its non-zero fields; use a
+// map to clear settings.
+func (c *SettingsClient) Update(ctx context.Context, patch any, fields ...string) (ProjectSettings, error) {
+	var s ProjectSettings
+	err := c.d.sendJSON(ctx, "PATCH", "/settings", fieldsQuery(fields), patch, &s)
+	return s, err
+}
@@ -0,0 +1,50 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings(t *testing.T) {
+	settings := map[string]any{
+		"id":           1,
+		"project_name": "Directus",
+		"module_bar":   []any{map[string]any{"type": "module", "id": "content", "enabled": true}},
+		"storage_asset_presets": []any{
+			map[string]any{"key": "thumb", "fit": "cover", "width": 200, "height": 200, "withoutEnlargement": true, "format": "webp"},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/settings", r.URL.Path)
+		if r.Method == "PATCH" {
+			var patch map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			for k, v := range patch {
+				settings[k] = v
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"data": settings})
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	s, err := client.Settings().Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Directus", s.ProjectName)
+	require.Equal(t, []AssetPreset{{Key: "thumb", Fit: FitCover, Width: 200, Height: 200, WithoutEnlargement: true, Format: FormatWebP}}, s.StorageAssetPresets)
+	require.JSONEq(t, `[{"type":"module","id":"content","enabled":true}]`, string(s.ModuleBar))
+
+	updated, err := client.Settings().Update(ctx, ProjectSettings{ProjectName: "Acme", ProjectColor: "#6644FF", ModuleBar: s.ModuleBar})
+	require.NoError(t, err)
+	require.Equal(t, "Acme", updated.ProjectName)
+	require.Equal(t, "#6644FF", updated.ProjectColor)
+	require.Len(t, updated.StorageAssetPresets, 1)
+}
@@ -0,0 +1,69 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShares(t *testing.T) {
+	var requests []string
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+		var body map[string]any
+		if r.Body != nil && r.Method != "GET" && r.Method != "DELETE" {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			bodies = append(bodies, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/shares":
+			body["id"] = "s1"
+			delete(body, "password")
+			json.NewEncoder(w).Encode(map[string]any{"data": body})
+		case "/shares/auth":
+			if body["password"] != "open sesame" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]any{"errors": []any{map[string]any{"message": "Invalid user credentials.", "extensions": map[string]any{"code": "INVALID_CREDENTIALS"}}}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"access_token": "share-token", "expires": 900000, "refresh_token": "share-refresh"}})
+		case "/shares/invite", "/shares/s1":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	share, err := client.Shares().Create(ctx, Share{Name: "Review", Collection: "articles", Item: "5", Role: "r1", Password: "open sesame", MaxUses: 3})
+	require.NoError(t, err)
+	require.Equal(t, Share{ID: "s1", Name: "Review", Collection: "articles", Item: "5", Role: "r1", MaxUses: 3}, share)
+
+	tokens, err := client.Shares().Auth(ctx, share.ID, "open sesame")
+	require.NoError(t, err)
+	require.Equal(t, "share-token", tokens.AccessToken)
+	require.Equal(t, "share-refresh", tokens.RefreshToken)
+	_, err = client.Shares().Auth(ctx, share.ID, "")
+	require.Error(t, err)
+
+	require.NoError(t, client.Shares().Invite(ctx, share.ID, "a@dev.io", "b@dev.io"))
+	require.NoError(t, client.Shares().Delete(ctx, share.ID))
+
+	require.Equal(t, []string{
+		"POST /shares Bearer token",
+		"POST /shares/auth ",
+		"POST /shares/auth ",
+		"POST /shares/invite Bearer token",
+		"DELETE /shares/s1 Bearer token",
+	}, requests)
+	require.Equal(t, map[string]any{"share": "s1", "password": "open sesame", "mode": "json"}, bodies[1])
+	require.Equal(t, map[string]any{"share": "s1", "mode": "json"}, bodies[2])
+	require.Equal(t, map[string]any{"share": "s1", "emails": []any{"a@dev.io", "b@dev.io"}}, bodies[3])
+}
@@ -0,0 +1,36 @@
+package directus_client
+
+import "strings"
+
+// SortRandom sorts items randomly.
+const SortRandom = "?"
+
+// SortBy starts a sort order, e.g.
+// SortBy("date_created").Desc().ThenBy("id") for "-date_created,id".
+func SortBy(field string) Fields {
+	return Fields{field}
+}
+
+// ThenBy returns f followed by field, ascending.
+func (f Fields) ThenBy(field string) Fields {
+	return append(f[:len(f):len(f)], field)
+}
+
+// Desc returns f with its last field sorted descending.
+func (f Fields) Desc() Fields {
+	return f.direction("-")
+}
+
+// Asc returns f with its last field sorted ascending.
+func (f Fields) Asc() Fields {
+	return f.direction("")
+}
+
+func (f Fields) direction(prefix string) Fields {
+	if len(f) == 0 || f[len(f)-1] == SortRandom {
+		return f
+	}
+	out := append(Fields(nil), f...)
+	out[len(out)-1] = prefix + strings.TrimPrefix(out[len(out)-1], "-")
+	return out
+}
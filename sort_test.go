@@ -0,0 +1,28 @@
+package directus_client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortBy(t *testing.T) {
+	base := SortBy("date_created").Desc()
+	require.Equal(t, Fields{"-date_created", "id"}, base.ThenBy("id"))
+	require.Equal(t, Fields{"-date_created", "-title"}, base.ThenBy("title").Desc())
+	require.Equal(t, Fields{"-date_created"}, base, "builders don't modify their receiver")
+	require.Equal(t, Fields{"date_created"}, base.Asc())
+	require.Equal(t, Fields{"date_created"}, SortBy("date_created").Desc().Desc().Asc())
+	require.Equal(t, Fields{"status", SortRandom}, SortBy("status").ThenBy(SortRandom).Desc())
+
+	q := DirectusQuery{Filter: Filter{}, Sort: SortBy(SortRandom)}
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "?", v.Get("sort"))
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, Fields{"?"}, parsed.Sort)
+	_, err = ParseQuery(url.Values{"filter": {"{}"}, "sort": {"-?"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RefreshTokenCookie is the default name of the cookie Directus sets to the
+// refresh token after an SSO login, see REFRESH_TOKEN_COOKIE_NAME.
+const RefreshTokenCookie = "directus_refresh_token"
+
+// AuthProvider is an SSO provider configured in AUTH_PROVIDERS.
+type AuthProvider struct {
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+	Icon   string `json:"icon,omitempty"`
+}
+
+// AuthProviders lists the SSO providers of /auth. Directus reports whether
+// email/password logins are disabled in addition.
+func (d *DirectusClient) AuthProviders(ctx context.Context) (providers []AuthProvider, disableDefault bool, err error) {
+	resp, err := d.callPublic(ctx, "GET", "/auth", nil, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, responseError(resp, resp.Body)
+	}
+	var result struct {
+		Data           []AuthProvider `json:"data"`
+		DisableDefault bool           `json:"disableDefault"`
+	}
+	if err := decodeJSON(resp.Body, &result); err != nil {
+		return nil, false, err
+	}
+	return result.Data, result.DisableDefault, nil
+}
+
+// SSOLoginURL returns the URL starting the login with provider. Directus
+// redirects to redirect afterwards, which must be allowed by the
+// AUTH_<PROVIDER>_REDIRECT_ALLOW_LIST, with the refresh token set as cookie,
+// see RefreshTokenFromRequest.
+func (d *DirectusClient) SSOLoginURL(provider string, redirect string) string {
+	u := &url.URL{Scheme: d.baseURL.Scheme, Host: d.baseURL.Host, Path: "/auth/login/" + url.PathEscape(provider)}
+	if redirect != "" {
+		u.RawQuery = url.Values{"redirect": {redirect}}.Encode()
+	}
+	return u.String()
+}
+
+// RefreshTokenFromRequest returns the refresh token Directus set as cookie
+// on the SSO callback request r. It only reaches the callback when Directus
+// and the callback share a cookie domain.
+func RefreshTokenFromRequest(r *http.Request) (string, error) {
+	c, err := r.Cookie(RefreshTokenCookie)
+	if err != nil {
+		return "", err
+	}
+	return c.Value, nil
+}
+
+// NewRefreshToken returns a LoginToken for the session of refreshToken, e.g.
+// the one of an SSO login. Unlike NewLoginToken it cannot log in again once
+// the refresh token expired.
+func NewRefreshToken(refreshToken string) *LoginToken {
+	return &LoginToken{tokens: AuthTokens{RefreshToken: refreshToken}}
+}
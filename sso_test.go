@@ -0,0 +1,57 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthProviders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/auth", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"name":"github","driver":"oauth2","icon":"github"},{"name":"keycloak","driver":"openid"}],"disableDefault":true}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL+"/", "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	providers, disableDefault, err := client.AuthProviders(context.Background())
+	require.NoError(t, err)
+	require.True(t, disableDefault)
+	require.Equal(t, []AuthProvider{{"github", "oauth2", "github"}, {"keycloak", "openid", ""}}, providers)
+
+	require.Equal(t, srv.URL+"/auth/login/github?redirect=https%3A%2F%2Fapp.enku.chat%2Fcallback", client.SSOLoginURL("github", "https://app.enku.chat/callback"))
+}
+
+func TestRefreshToken(t *testing.T) {
+	srv := newAuthServer(t)
+	ctx := context.Background()
+	login, err := NewDirectusClientWithLogin(ctx, srv.URL, "dev@dev.io", "secret", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	// the callback of an SSO login receives the refresh token as cookie
+	callback := httptest.NewRequest("GET", "/callback", nil)
+	callback.AddCookie(&http.Cookie{Name: RefreshTokenCookie, Value: login.Tokens().RefreshToken})
+	refreshToken, err := RefreshTokenFromRequest(callback)
+	require.NoError(t, err)
+	_, err = RefreshTokenFromRequest(httptest.NewRequest("GET", "/callback", nil))
+	require.ErrorIs(t, err, http.ErrNoCookie)
+
+	client, err := NewDirectusClientWithTokenProvider(srv.URL, NewRefreshToken(refreshToken), NewNoopQueryCache())
+	require.NoError(t, err)
+	resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	require.Equal(t, "a2", client.Tokens().AccessToken)
+
+	// without a password an invalid refresh token is final
+	stale, err := NewDirectusClientWithTokenProvider(srv.URL, NewRefreshToken("stale"), NewNoopQueryCache())
+	require.NoError(t, err)
+	_, err = stale.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.EqualError(t, err, "directus: 401 Unauthorized: Invalid user credentials.")
+}
@@ -0,0 +1,31 @@
+package directus_client
+
+import "context"
+
+// Stream sends the items matching query on the returned channel as pages
+// arrive, see Iterate, for jobs processing more items than fit in memory. At
+// most one page is buffered, so a slow consumer holds back the requests.
+// Both channels are closed when the items are exhausted, the request of a
+// page failed or ctx is done; the error channel then yields the error, if
+// any. The consumer must drain the items or cancel ctx.
+func (c *ItemsClient[T]) Stream(ctx context.Context, query DirectusQuery) (<-chan T, <-chan error) {
+	it := c.Iterate(ctx, query)
+	items := make(chan T, it.query.Limit)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(items)
+		for it.Next() {
+			select {
+			case items <- it.Item():
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errc <- err
+		}
+	}()
+	return items, errc
+}
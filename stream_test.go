@@ -0,0 +1,43 @@
+package directus_client
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.enkuchat.com/backend/directus_client/emulator"
+)
+
+func TestStream(t *testing.T) {
+	e := emulator.New("token")
+	for i := 0; i < 25; i++ {
+		e.Seed("articles", map[string]any{"title": "a"})
+	}
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	query := DirectusQuery{Filter: Filter{"id": {OP_nnull: true}}, Sort: Fields{"id"}, Limit: 10}
+
+	items, errc := articles.Stream(context.Background(), query)
+	var ids []int
+	for item := range items {
+		ids = append(ids, item.ID)
+	}
+	require.NoError(t, <-errc)
+	require.Len(t, ids, 25)
+	require.Equal(t, 1, ids[0])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items, errc = articles.Stream(ctx, query)
+	<-items
+	cancel()
+	for range items {
+	}
+	require.ErrorIs(t, <-errc, context.Canceled)
+
+	_, errc = Items[article](client, "missing").Stream(context.Background(), query)
+	require.ErrorIs(t, <-errc, ErrForbidden)
+}
@@ -0,0 +1,203 @@
+package directus_client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// callSystem sends a request to a Directus endpoint outside /items, such as
+// /server/health. It shares authentication, request ids, retries and failover
+// with Call, but bypasses the cache, the middleware and the circuit breaker.
+func (d *DirectusClient) callSystem(ctx context.Context, method string, path string, query url.Values, input io.Reader) (*http.Response, error) {
+	return d.callSystemContent(ctx, method, path, query, input, "application/json")
+}
+
+// callSystemContent is callSystem with an input of another content type.
+func (d *DirectusClient) callSystemContent(ctx context.Context, method string, path string, query url.Values, input io.Reader, contentType string) (*http.Response, error) {
+	token, err := d.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return d.callEndpoint(ctx, method, path, query, input, contentType, token)
+}
+
+// callPublic is callSystem without authentication, for /auth endpoints.
+func (d *DirectusClient) callPublic(ctx context.Context, method string, path string, query url.Values, input io.Reader) (*http.Response, error) {
+	return d.callEndpoint(ctx, method, path, query, input, "application/json", "")
+}
+
+func (d *DirectusClient) callEndpoint(ctx context.Context, method string, path string, query url.Values, input io.Reader, contentType string, token string) (*http.Response, error) {
+	u := &url.URL{Scheme: d.baseURL.Scheme, Host: d.baseURL.Host, Path: path, RawQuery: query.Encode()}
+	req := (&http.Request{Method: method, URL: u, Host: u.Host, Header: http.Header{}}).WithContext(ctx)
+	if input != nil {
+		req.Body = io.NopCloser(input)
+		req.GetBody = bodyReplayer(input)
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		d.setImpersonation(req)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	req = withRequestID(req)
+
+	if d.debug {
+		if err := d.dumpRequest(req); err != nil {
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
+	}
+	start := time.Now()
+	resp, err := d.send(req)
+	if err != nil {
+		return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+	}
+	if err := decompressBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+	}
+	if err := d.limitBody(resp); err != nil {
+		return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+	}
+	if d.debug {
+		if err := d.dumpResponse(req, resp, start); err != nil {
+			return nil, &RequestError{RequestIDFromContext(req.Context()), err}
+		}
+	}
+	return resp, nil
+}
+
+// readData decodes the data of a successful Directus response into v and
+// closes its body.
+func readData(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return responseError(resp, resp.Body)
+	}
+	if v == nil {
+		return nil
+	}
+	return decodeJSON(resp.Body, &struct {
+		Data any `json:"data"`
+	}{v})
+}
+
+// sendJSON sends body, if not nil, as JSON to a Directus endpoint outside
+// /items and decodes the data of the response into out, if not nil.
+func (d *DirectusClient) sendJSON(ctx context.Context, method string, path string, query url.Values, body any, out any) error {
+	var input io.Reader
+	if body != nil {
+		b, err := currentCodec().Marshal(body)
+		if err != nil {
+			return err
+		}
+		input = bytes.NewReader(b)
+	}
+	resp, err := d.callSystem(ctx, method, path, query, input)
+	if err != nil {
+		return err
+	}
+	return readData(resp, out)
+}
+
+// SystemClient reads and writes the records of a Directus system collection,
+// e.g. /users or /roles, as T. Unlike ItemsClient its requests bypass the
+// cache and the middleware, see callSystem. Use System to decode records
+// into your own types, e.g. users with their role expanded.
+type SystemClient[T any] struct {
+	d    *DirectusClient
+	path string
+}
+
+// System returns a client for the system collection at path, e.g.
+// System[MyUser](client, "/users").
+func System[T any](d *DirectusClient, path string) *SystemClient[T] {
+	return &SystemClient[T]{d: d, path: path}
+}
+
+// List returns the records matching query. Meta is zero unless query.Meta
+// is set.
+func (c *SystemClient[T]) List(ctx context.Context, query DirectusQuery) ([]T, Meta, error) {
+	if err := query.validateLimit(c.d.maxLimit); err != nil {
+		return nil, Meta{}, err
+	}
+	v, err := query.BuildQuery()
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	if c.d.bracketFilters {
+		if err := bracketFilter(v); err != nil {
+			return nil, Meta{}, err
+		}
+	}
+	resp, err := c.d.callSystem(ctx, "GET", c.path, v, nil)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	var records []T
+	var meta Meta
+	if err := readItems(resp, &records, &meta); err != nil {
+		return nil, Meta{}, err
+	}
+	return records, meta, nil
+}
+
+// Get returns the record with primary key id, reduced to fields if given.
+func (c *SystemClient[T]) Get(ctx context.Context, id any, fields ...string) (T, error) {
+	var record T
+	path, err := c.recordPath(id)
+	if err != nil {
+		return record, err
+	}
+	err = c.d.sendJSON(ctx, "GET", path, fieldsQuery(fields), nil, &record)
+	return record, err
+}
+
+// Create creates record and returns it as stored by Directus, reduced to
+// fields if given.
+func (c *SystemClient[T]) Create(ctx context.Context, record T, fields ...string) (T, error) {
+	var created T
+	err := c.d.sendJSON(ctx, "POST", c.path, fieldsQuery(fields), record, &created)
+	return created, err
+}
+
+// Update patches the record with primary key id and returns it as stored by
+// Directus, reduced to fields if given. As with ItemsClient.Update, every
+// field patch encodes is written.
+func (c *SystemClient[T]) Update(ctx context.Context, id any, patch any, fields ...string) (T, error) {
+	var updated T
+	path, err := c.recordPath(id)
+	if err != nil {
+		return updated, err
+	}
+	err = c.d.sendJSON(ctx, "PATCH", path, fieldsQuery(fields), patch, &updated)
+	return updated, err
+}
+
+// Delete deletes the record with primary key id.
+func (c *SystemClient[T]) Delete(ctx context.Context, id any) error {
+	path, err := c.recordPath(id)
+	if err != nil {
+		return err
+	}
+	return c.d.sendJSON(ctx, "DELETE", path, nil, nil, nil)
+}
+
+func (c *SystemClient[T]) recordPath(id any) (string, error) {
+	return systemPath(c.path, id)
+}
+
+// systemPath appends keys, e.g. a collection and a field name, to path.
+func systemPath(path string, keys ...any) (string, error) {
+	for _, k := range keys {
+		key, err := formatKey(k)
+		if err != nil {
+			return "", err
+		}
+		path += "/" + key
+	}
+	return path, nil
+}
@@ -0,0 +1,184 @@
+package directus_client
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// placeholder matches {{.Name}} in filter values.
+var placeholder = regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// QueryTemplate is a query whose filter values contain named placeholders,
+// bound to values when the query is run, so canned queries can be kept in
+// configuration instead of concatenated into filter JSON:
+//
+//	t, err := ParseQueryTemplate([]byte(`{"filter":{"tenant":{"_eq":"{{.TenantID}}"}},"limit":10}`))
+//	query, err := t.Bind(map[string]any{"TenantID": 42})
+//
+// A value consisting of a single placeholder is replaced by the bound value
+// as it is, keeping its type, e.g. a number or a slice for _in. Placeholders
+// within longer strings are replaced by the bound values formatted with %v.
+type QueryTemplate struct {
+	query  DirectusQuery
+	params []string
+}
+
+// NewQueryTemplate returns a template of query.
+func NewQueryTemplate(query DirectusQuery) *QueryTemplate {
+	names := map[string]bool{}
+	walkPlaceholders(query.Filter, func(s string) {
+		for _, m := range placeholder.FindAllStringSubmatch(s, -1) {
+			names[m[1]] = true
+		}
+	})
+	params := make([]string, 0, len(names))
+	for name := range names {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	return &QueryTemplate{query: query, params: params}
+}
+
+// ParseQueryTemplate returns a template of the JSON encoded query data, see
+// DirectusQuery.UnmarshalJSON.
+func ParseQueryTemplate(data []byte) (*QueryTemplate, error) {
+	var query DirectusQuery
+	if err := query.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return NewQueryTemplate(query), nil
+}
+
+// Params returns the sorted names of the placeholders of t.
+func (t *QueryTemplate) Params() []string {
+	return append([]string(nil), t.params...)
+}
+
+// Bind returns the query of t with its placeholders replaced by the values of
+// data, a map with string keys or a struct or pointer to one with exported
+// fields of the placeholder names. It fails if a value is missing.
+func (t *QueryTemplate) Bind(data any) (DirectusQuery, error) {
+	values := make(map[string]any, len(t.params))
+	for _, name := range t.params {
+		v, err := templateValue(data, name)
+		if err != nil {
+			return DirectusQuery{}, err
+		}
+		values[name] = v
+	}
+	query := t.query
+	if query.Filter != nil {
+		query.Filter = bindValue(query.Filter, values).(FilterNode)
+	}
+	return query, nil
+}
+
+func templateValue(data any, name string) (any, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			if e := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); e.IsValid() {
+				return e.Interface(), nil
+			}
+		}
+	case reflect.Struct:
+		if f := v.FieldByName(name); f.IsValid() && f.CanInterface() {
+			return f.Interface(), nil
+		}
+	}
+	return nil, errors.New("query template: no value for " + name)
+}
+
+// bindValue returns a copy of v with placeholders replaced.
+func bindValue(v any, values map[string]any) any {
+	switch v := v.(type) {
+	case string:
+		if m := placeholder.FindStringSubmatch(v); m != nil && m[0] == v {
+			return values[m[1]]
+		}
+		return placeholder.ReplaceAllStringFunc(v, func(s string) string {
+			return fmt.Sprint(values[placeholder.FindStringSubmatch(s)[1]])
+		})
+	case Filter:
+		f := make(Filter, len(v))
+		for field, ops := range v {
+			f[field] = make(map[FilterOperator]any, len(ops))
+			for op, o := range ops {
+				f[field][op] = bindValue(o, values)
+			}
+		}
+		return f
+	case And:
+		a := make(And, len(v))
+		for i, n := range v {
+			a[i] = bindValue(n, values).(FilterNode)
+		}
+		return a
+	case Or:
+		o := make(Or, len(v))
+		for i, n := range v {
+			o[i] = bindValue(n, values).(FilterNode)
+		}
+		return o
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, o := range v {
+			m[k] = bindValue(o, values)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, o := range v {
+			l[i] = bindValue(o, values)
+		}
+		return l
+	case []string:
+		l := make([]any, len(v))
+		for i, o := range v {
+			l[i] = bindValue(o, values)
+		}
+		return l
+	}
+	return v
+}
+
+// walkPlaceholders calls fn with the strings of v that may hold placeholders.
+func walkPlaceholders(v any, fn func(string)) {
+	switch v := v.(type) {
+	case string:
+		fn(v)
+	case Filter:
+		for _, ops := range v {
+			for _, o := range ops {
+				walkPlaceholders(o, fn)
+			}
+		}
+	case And:
+		for _, n := range v {
+			walkPlaceholders(n, fn)
+		}
+	case Or:
+		for _, n := range v {
+			walkPlaceholders(n, fn)
+		}
+	case map[string]any:
+		for _, o := range v {
+			walkPlaceholders(o, fn)
+		}
+	case []any:
+		for _, o := range v {
+			walkPlaceholders(o, fn)
+		}
+	case []string:
+		for _, o := range v {
+			fn(o)
+		}
+	}
+}
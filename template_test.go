@@ -0,0 +1,45 @@
+package directus_client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTemplate(t *testing.T) {
+	tmpl, err := ParseQueryTemplate([]byte(`{
+		"filter": {"_and": [
+			{"tenant": {"_eq": "{{.TenantID}}"}},
+			{"id": {"_in": "{{ .IDs }}"}},
+			{"slug": {"_starts_with": "{{.TenantID}}-{{.Prefix}}"}}
+		]},
+		"limit": 10
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"IDs", "Prefix", "TenantID"}, tmpl.Params())
+
+	query, err := tmpl.Bind(map[string]any{"TenantID": 42, "IDs": []int{1, 2}, "Prefix": "news"})
+	require.NoError(t, err)
+	require.Equal(t, FilterNode(And{
+		Filter{"tenant": {OP_eq: 42}},
+		Filter{"id": {OP_in: []int{1, 2}}},
+		Filter{"slug": {OP_starts_with: "42-news"}},
+	}), query.Filter)
+	require.Equal(t, 10, query.Limit)
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"_and":[{"tenant":{"_eq":42}},{"id":{"_in":[1,2]}},{"slug":{"_starts_with":"42-news"}}]}`, v.Get("filter"))
+
+	// structs bind by field name, the template is left as it is
+	tmpl = NewQueryTemplate(DirectusQuery{Filter: Filter{"owner": {OP_eq: "{{.UserID}}"}, "status": {OP_in: []string{"{{.Status}}", "draft"}}}})
+	query, err = tmpl.Bind(&struct {
+		UserID string
+		Status string
+	}{"u1", "published"})
+	require.NoError(t, err)
+	require.Equal(t, FilterNode(Filter{"owner": {OP_eq: "u1"}, "status": {OP_in: []any{"published", "draft"}}}), query.Filter)
+	require.Equal(t, "{{.UserID}}", tmpl.query.Filter.(Filter)["owner"][OP_eq])
+
+	_, err = tmpl.Bind(map[string]any{"UserID": "u1"})
+	require.EqualError(t, err, "query template: no value for Status")
+}
@@ -0,0 +1,163 @@
+package directus_client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// WithHTTP1 disables HTTP/2. By default HTTP/2 is negotiated with Directus
+// over TLS, which multiplexes all requests over a single connection.
+func WithHTTP1() ClientOption {
+	return func(d *DirectusClient) {
+		d.configureTransport(func(t *http.Transport) {
+			t.ForceAttemptHTTP2 = false
+			t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			if t.TLSClientConfig != nil {
+				t.TLSClientConfig.NextProtos = []string{"http/1.1"}
+			}
+		})
+	}
+}
+
+// WithIdleConns sets how many idle connections are kept per host and for how
+// long. Raise it for HTTP/1.1 under high concurrency, lower it to free
+// connections on a quiet Directus.
+func WithIdleConns(perHost int, timeout time.Duration) ClientOption {
+	return func(d *DirectusClient) {
+		d.configureTransport(func(t *http.Transport) {
+			t.MaxIdleConnsPerHost = perHost
+			if t.MaxIdleConns != 0 && t.MaxIdleConns < perHost {
+				t.MaxIdleConns = perHost
+			}
+			t.IdleConnTimeout = timeout
+		})
+	}
+}
+
+// WithTransport sends requests through rt, e.g. an instrumented or
+// proxy-aware RoundTripper, keeping the timeout of the default client.
+// Transport options such as WithHTTP1 only apply to *http.Transport.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(d *DirectusClient) {
+		c := *d.client
+		c.Transport = rt
+		d.client = &c
+	}
+}
+
+// WithProxy routes requests through an HTTP(S) proxy. Without it the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+func WithProxy(proxyURL string) ClientOption {
+	return func(d *DirectusClient) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			d.optErr = err
+			return
+		}
+		d.configureTransport(func(t *http.Transport) {
+			t.Proxy = http.ProxyURL(u)
+		})
+	}
+}
+
+// configureTransport applies f to a copy of the transport of the client, so
+// the shared transport and transports passed to WithHTTPClient are left
+// untouched.
+func (d *DirectusClient) configureTransport(f func(*http.Transport)) {
+	rt := d.client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		d.log.Warn("transport options ignored for custom http.RoundTripper")
+		return
+	}
+	t = t.Clone()
+	f(t)
+	c := *d.client
+	c.Transport = t
+	d.client = &c
+}
+
+// ConnStats counts the connections used by requests sent to Directus; cache
+// hits and deduplicated requests are not included.
+type ConnStats struct {
+	Requests    uint64
+	NewConns    uint64
+	ReusedConns uint64
+	HTTP2       uint64
+}
+
+type connStats struct {
+	requests, newConns, reusedConns, http2 uint64
+	trace                                  *httptrace.ClientTrace
+}
+
+func newConnStats() *connStats {
+	s := new(connStats)
+	s.trace = &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				atomic.AddUint64(&s.reusedConns, 1)
+			} else {
+				atomic.AddUint64(&s.newConns, 1)
+			}
+		},
+	}
+	return s
+}
+
+func (s *connStats) observe(resp *http.Response) {
+	atomic.AddUint64(&s.requests, 1)
+	if resp.ProtoMajor == 2 {
+		atomic.AddUint64(&s.http2, 1)
+	}
+}
+
+// ConnStats reports connection reuse since the client was created.
+func (d *DirectusClient) ConnStats() ConnStats {
+	return ConnStats{
+		Requests:    atomic.LoadUint64(&d.stats.requests),
+		NewConns:    atomic.LoadUint64(&d.stats.newConns),
+		ReusedConns: atomic.LoadUint64(&d.stats.reusedConns),
+		HTTP2:       atomic.LoadUint64(&d.stats.http2),
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to Directus, e.g.
+// RootCAs for an internal CA. cfg is copied.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(d *DirectusClient) {
+		d.configureTransport(func(t *http.Transport) {
+			cfg := cfg.Clone()
+			// keep HTTP/2 negotiation set up by the transport
+			if len(cfg.NextProtos) == 0 && t.TLSClientConfig != nil {
+				cfg.NextProtos = t.TLSClientConfig.NextProtos
+			}
+			t.TLSClientConfig = cfg
+		})
+	}
+}
+
+// WithCertificate loads a PEM encoded client certificate and key for mutual
+// TLS. Use it after WithTLSConfig, which replaces the certificates.
+func WithCertificate(certFile string, keyFile string) ClientOption {
+	return func(d *DirectusClient) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			d.optErr = err
+			return
+		}
+		d.configureTransport(func(t *http.Transport) {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = new(tls.Config)
+			}
+			t.TLSClientConfig.Certificates = append(t.TLSClientConfig.Certificates, cert)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package directus_client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP2AndConnStats(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name  string
+		opts  []ClientOption
+		http2 uint64
+	}{
+		{"default", nil, 2},
+		{"http1", []ClientOption{WithHTTP1()}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]ClientOption{WithHTTPClient(srv.Client())}, tc.opts...)
+			client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), opts...)
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+				require.NoError(t, err)
+				result := ReadResult[[]user](resp)
+				require.False(t, result.Err())
+			}
+			require.Equal(t, ConnStats{Requests: 2, NewConns: 1, ReusedConns: 1, HTTP2: tc.http2}, client.ConnStats())
+		})
+	}
+	// options work on a copy of the transport
+	require.True(t, srv.Client().Transport.(*http.Transport).ForceAttemptHTTP2)
+}
+
+func TestMutualTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Len(t, r.TLS.PeerCertificates, 1)
+		require.Equal(t, "directus-client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	certFile, keyFile := writeClientCertificate(t)
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithTLSConfig(&tls.Config{RootCAs: roots}), WithCertificate(certFile, keyFile))
+	require.NoError(t, err)
+	resp, err := client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// without the client certificate the handshake fails
+	client, err = NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithTLSConfig(&tls.Config{RootCAs: roots}))
+	require.NoError(t, err)
+	_, err = client.Query("GET", "user", DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}, nil)
+	require.Error(t, err)
+
+	_, err = NewDirectusClient(srv.URL, "token", NewNoopQueryCache(), WithCertificate("missing.pem", "missing.key"))
+	require.Error(t, err)
+}
+
+// writeClientCertificate writes a self-signed certificate and its key as PEM
+// files.
+func writeClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "directus-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportAndProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer proxy.Close()
+	query := DirectusQuery{Filter: Filter{"id": {OP_eq: 1}}}
+
+	client, err := NewDirectusClient("http://directus.internal", "token", NewNoopQueryCache(), WithProxy(proxy.URL))
+	require.NoError(t, err)
+	resp, err := client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, proxied, 1)
+	require.Contains(t, proxied[0], "http://directus.internal/items/user?")
+
+	var sent []*http.Request
+	client, err = NewDirectusClient("http://directus.internal", "token", NewNoopQueryCache(), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent = append(sent, req)
+		return &http.Response{StatusCode: http.StatusTeapot, Body: http.NoBody, Request: req}, nil
+	})))
+	require.NoError(t, err)
+	resp, err = client.Query("GET", "user", query, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTeapot, resp.StatusCode)
+	require.Len(t, sent, 1)
+	require.Equal(t, "Bearer token", sent[0].Header.Get("Authorization"))
+}
@@ -0,0 +1,91 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// FileUploadOptions describes a file uploaded with UploadFile. Filename is
+// required; ContentType defaults to application/octet-stream, and Directus
+// detects images and their dimensions itself.
+type FileUploadOptions struct {
+	Filename    string
+	ContentType string
+	Title       string
+	Description string
+	Folder      string
+	Tags        []string
+	// Storage is the storage location, the first of STORAGE_LOCATIONS if
+	// empty.
+	Storage string
+}
+
+// UploadFile uploads the content of r as a new file and returns its record.
+// The content is streamed rather than buffered, so the request is not
+// retried.
+func (d *DirectusClient) UploadFile(ctx context.Context, r io.Reader, opts FileUploadOptions) (DirectusFile, error) {
+	var file DirectusFile
+	if opts.Filename == "" {
+		return file, errors.New("directus: upload requires a filename")
+	}
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeUpload(mw, r, opts))
+	}()
+	resp, err := d.callSystemContent(ctx, "POST", "/files", nil, pr, mw.FormDataContentType())
+	// unblock the writer if the request failed before reading the body
+	pr.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		return file, err
+	}
+	err = readData(resp, &file)
+	return file, err
+}
+
+// writeUpload writes the metadata fields of opts, which Directus requires
+// before the file, then the content of r.
+func writeUpload(mw *multipart.Writer, r io.Reader, opts FileUploadOptions) error {
+	fields := [][2]string{
+		{"storage", opts.Storage},
+		{"title", opts.Title},
+		{"description", opts.Description},
+		{"folder", opts.Folder},
+	}
+	if len(opts.Tags) > 0 {
+		b, err := currentCodec().Marshal(opts.Tags)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, [2]string{"tags", string(b)})
+	}
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		if err := mw.WriteField(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="file"; filename="`+quoteEscaper.Replace(opts.Filename)+`"`)
+	h.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+	return mw.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,64 @@
+package directus_client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/files", r.URL.Path)
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		mr, err := r.MultipartReader()
+		require.NoError(t, err)
+		var names []string
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			b, err := io.ReadAll(part)
+			require.NoError(t, err)
+			names = append(names, part.FormName())
+			switch part.FormName() {
+			case "title":
+				require.Equal(t, "Report", string(b))
+			case "tags":
+				require.Equal(t, `["q1","finance"]`, string(b))
+			case "file":
+				require.Equal(t, `report "2024".pdf`, part.FileName())
+				require.Equal(t, "application/pdf", part.Header.Get("Content-Type"))
+				require.Equal(t, "%PDF-1.7", string(b))
+			}
+		}
+		// metadata must precede the file
+		require.Equal(t, []string{"title", "folder", "tags", "file"}, names)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":"f1","title":"Report","type":"application/pdf","filesize":"8"}}`))
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	f, err := client.UploadFile(ctx, strings.NewReader("%PDF-1.7"), FileUploadOptions{
+		Filename:    `report "2024".pdf`,
+		ContentType: "application/pdf",
+		Title:       "Report",
+		Folder:      "fo1",
+		Tags:        []string{"q1", "finance"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, DirectusFile{ID: "f1", Title: "Report", Type: "application/pdf", Filesize: 8}, f)
+
+	_, err = client.UploadFile(ctx, strings.NewReader(""), FileUploadOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package directus_client
+
+import (
+	"context"
+	"time"
+)
+
+// InviteUser invites users by email into role, a role id. Directus emails
+// them a link to inviteURL, which must be allowed by
+// USER_INVITE_URL_ALLOW_LIST, or the Data Studio if it is empty; the invite
+// token is appended as query parameter "token".
+func (d *DirectusClient) InviteUser(ctx context.Context, role string, inviteURL string, emails ...string) error {
+	body := map[string]any{"email": emails, "role": role}
+	if inviteURL != "" {
+		body["invite_url"] = inviteURL
+	}
+	return d.sendJSON(ctx, "POST", "/users/invite", nil, body, nil)
+}
+
+// AcceptInvite activates the invited user of token with password. It needs
+// no authentication.
+func (d *DirectusClient) AcceptInvite(ctx context.Context, token string, password string) error {
+	return d.postPublic(ctx, "/users/invite/accept", map[string]string{
+		"token":    token,
+		"password": password,
+	})
+}
+
+// User is a Directus user. Role is the id of the role of the user; use
+// System with a type of your own to expand it or to read custom fields.
+// Password is write-only.
+type User struct {
+	ID                 string     `json:"id,omitempty"`
+	FirstName          string     `json:"first_name,omitempty"`
+	LastName           string     `json:"last_name,omitempty"`
+	Email              string     `json:"email,omitempty"`
+	Password           string     `json:"password,omitempty"`
+	Location           string     `json:"location,omitempty"`
+	Title              string     `json:"title,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Tags               []string   `json:"tags,omitempty"`
+	Avatar             string     `json:"avatar,omitempty"`
+	Language           string     `json:"language,omitempty"`
+	Status             string     `json:"status,omitempty"`
+	Role               string     `json:"role,omitempty"`
+	Token              string     `json:"token,omitempty"`
+	LastAccess         *time.Time `json:"last_access,omitempty"`
+	LastPage           string     `json:"last_page,omitempty"`
+	Provider           string     `json:"provider,omitempty"`
+	ExternalIdentifier string     `json:"external_identifier,omitempty"`
+	EmailNotifications *bool      `json:"email_notifications,omitempty"`
+}
+
+// UsersClient manages the users of Directus at /users.
+type UsersClient struct {
+	*SystemClient[User]
+}
+
+// Users returns a client for the users of Directus.
+func (d *DirectusClient) Users() *UsersClient {
+	return &UsersClient{System[User](d, "/users")}
+}
+
+// Me returns the user the client authenticates as, reduced to fields if
+// given.
+func (c *UsersClient) Me(ctx context.Context, fields ...string) (User, error) {
+	var me User
+	err := c.d.sendJSON(ctx, "GET", "/users/me", fieldsQuery(fields), nil, &me)
+	return me, err
+}
+
+// UpdateMe patches the user the client authenticates as and returns the
+// updated user.
+func (c *UsersClient) UpdateMe(ctx context.Context, patch any, fields ...string) (User, error) {
+	var me User
+	err := c.d.sendJSON(ctx, "PATCH", "/users/me", fieldsQuery(fields), patch, &me)
+	return me, err
+}
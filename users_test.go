@@ -0,0 +1,115 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvite(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/users/invite":
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.Equal(t, map[string]any{
+				"email":      []any{"a@dev.io", "b@dev.io"},
+				"role":       "c86c2761-65d3-43c3-897f-6f74ad6a5bd7",
+				"invite_url": "https://app.enku.chat/welcome",
+			}, body)
+		case "/users/invite/accept":
+			require.Empty(t, r.Header.Get("Authorization"))
+			require.Equal(t, map[string]any{"token": "invite-token", "password": "s3cret"}, body)
+		}
+		got = append(got, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.InviteUser(ctx, "c86c2761-65d3-43c3-897f-6f74ad6a5bd7", "https://app.enku.chat/welcome", "a@dev.io", "b@dev.io"))
+	require.NoError(t, client.AcceptInvite(ctx, "invite-token", "s3cret"))
+	require.Equal(t, []string{"/users/invite", "/users/invite/accept"}, got)
+}
+
+func TestUsers(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.String())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /users":
+			w.Write([]byte(`{"data":[{"id":"u1","email":"a@dev.io","role":"r1"}],"meta":{"filter_count":1}}`))
+		case "GET /users/u1", "GET /users/me":
+			w.Write([]byte(`{"data":{"id":"u1","email":"a@dev.io","last_access":"2024-05-01T10:00:00Z"}}`))
+		case "POST /users", "PATCH /users/u1", "PATCH /users/me":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			body["id"] = "u1"
+			delete(body, "password")
+			json.NewEncoder(w).Encode(map[string]any{"data": body})
+		case "DELETE /users/u1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":[{"message":"You don't have permission to access this.","extensions":{"code":"FORBIDDEN"}}]}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	users := client.Users()
+	ctx := context.Background()
+
+	meta := MetaQueryFilterCount
+	list, m, err := users.List(ctx, DirectusQuery{Filter: Filter{"role": {OP_eq: "r1"}}, Limit: 10, Meta: &meta})
+	require.NoError(t, err)
+	require.Equal(t, []User{{ID: "u1", Email: "a@dev.io", Role: "r1"}}, list)
+	require.Equal(t, 1, m.FilterCount)
+
+	u, err := users.Get(ctx, "u1", "id", "email", "last_access")
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-01T10:00:00Z", u.LastAccess.Format(time.RFC3339))
+
+	created, err := users.Create(ctx, User{Email: "b@dev.io", Password: "s3cret", Role: "r1"})
+	require.NoError(t, err)
+	require.Equal(t, User{ID: "u1", Email: "b@dev.io", Role: "r1"}, created)
+
+	updated, err := users.Update(ctx, "u1", map[string]any{"title": "Editor"})
+	require.NoError(t, err)
+	require.Equal(t, "Editor", updated.Title)
+	require.NoError(t, users.Delete(ctx, "u1"))
+
+	me, err := users.Me(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "u1", me.ID)
+	me, err = users.UpdateMe(ctx, map[string]any{"language": "de-DE"})
+	require.NoError(t, err)
+	require.Equal(t, "de-DE", me.Language)
+
+	_, err = users.Get(ctx, "u2")
+	require.ErrorIs(t, err, ErrForbidden)
+	_, err = users.Get(ctx, "a/b")
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		`GET /users?filter=%7B%22role%22%3A%7B%22_eq%22%3A%22r1%22%7D%7D&limit=10&meta=filter_count`,
+		"GET /users/u1?fields=id%2Cemail%2Clast_access",
+		"POST /users",
+		"PATCH /users/u1",
+		"DELETE /users/u1",
+		"GET /users/me",
+		"PATCH /users/me",
+		"GET /users/u2",
+	}, requests)
+}
@@ -0,0 +1,39 @@
+package directus_client
+
+import "context"
+
+// Sort moves the item of collection with primary key itemID to the position
+// of the item with primary key toID, shifting the items in between, as
+// dragging it in the Data Studio does. collection must have a sort field,
+// see CollectionMeta.SortField.
+func (d *DirectusClient) Sort(ctx context.Context, collection string, itemID any, toID any) error {
+	path, err := systemPath("/utils/sort", collection)
+	if err != nil {
+		return err
+	}
+	for _, id := range []any{itemID, toID} {
+		if _, err := formatKey(id); err != nil {
+			return err
+		}
+	}
+	return d.sendJSON(ctx, "POST", path, nil, map[string]any{"item": itemID, "to": toID}, nil)
+}
+
+// ClearServerCache clears the data cache of Directus, e.g. in a deployment
+// hook after schema changes. It needs admin access.
+func (d *DirectusClient) ClearServerCache(ctx context.Context) error {
+	return d.sendJSON(ctx, "POST", "/utils/cache/clear", nil, nil, nil)
+}
+
+// ClearCaches clears the data cache of Directus, then the QueryCache of d if
+// it is a ClearableQueryCache, so the local cache can't be refilled from the
+// stale server cache.
+func (d *DirectusClient) ClearCaches(ctx context.Context) error {
+	if err := d.ClearServerCache(ctx); err != nil {
+		return err
+	}
+	if c, ok := d.cache.(ClearableQueryCache); ok {
+		return c.Clear()
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package directus_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSort(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		got = append(got, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	client, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, client.Sort(ctx, "articles", 5, 2))
+	require.NoError(t, client.Sort(ctx, "pages", "about", "home"))
+	require.Error(t, client.Sort(ctx, "articles", nil, 2))
+	require.Error(t, client.Sort(ctx, "", 5, 2))
+	require.Equal(t, []string{
+		`POST /utils/sort/articles {"item":5,"to":2}`,
+		`POST /utils/sort/pages {"item":"about","to":"home"}`,
+	}, got)
+}
+
+func TestClearCaches(t *testing.T) {
+	var reads, clears int32
+	var denied atomic.Value
+	denied.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /utils/cache/clear":
+			if denied.Load().(bool) {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"errors":[{"message":"You don't have permission to access this.","extensions":{"code":"FORBIDDEN"}}]}`))
+				return
+			}
+			atomic.AddInt32(&clears, 1)
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /items/articles":
+			atomic.AddInt32(&reads, 1)
+			w.Write([]byte(`{"data":[{"id":1,"title":"Hello"}]}`))
+		}
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+	ctx := context.Background()
+	articles := Items[article](client, "articles")
+	list := func() {
+		_, _, err := articles.List(ctx, DirectusQuery{})
+		require.NoError(t, err)
+	}
+
+	list()
+	list()
+	require.Equal(t, int32(1), atomic.LoadInt32(&reads))
+
+	// the local cache is kept if the server cache can't be cleared
+	require.ErrorIs(t, client.ClearCaches(ctx), ErrForbidden)
+	list()
+	require.Equal(t, int32(1), atomic.LoadInt32(&reads))
+
+	denied.Store(false)
+	require.NoError(t, client.ClearServerCache(ctx))
+	list()
+	require.Equal(t, int32(1), atomic.LoadInt32(&reads))
+	require.NoError(t, client.ClearCaches(ctx))
+	list()
+	require.Equal(t, int32(2), atomic.LoadInt32(&reads))
+	require.Equal(t, int32(2), atomic.LoadInt32(&clears))
+
+	noCache, err := NewDirectusClient(srv.URL, "token", NewNoopQueryCache())
+	require.NoError(t, err)
+	require.NoError(t, noCache.ClearCaches(ctx))
+}
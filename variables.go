@@ -0,0 +1,49 @@
+package directus_client
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Variable is a dynamic variable Directus resolves in filter values when
+// running a query, e.g. Filter{"date_created": {OP_gte: NowOffset("-1 week")}}.
+type Variable string
+
+const (
+	varNow         Variable = "$NOW"
+	varCurrentUser Variable = "$CURRENT_USER"
+	varCurrentRole Variable = "$CURRENT_ROLE"
+)
+
+// Now is the time the query runs.
+func Now() Variable {
+	return varNow
+}
+
+// NowOffset is the time the query runs, adjusted by offset, a signed amount
+// and unit such as "-1 week", "+2 hours" or "-30 minutes".
+func NowOffset(offset string) Variable {
+	return varNow + "(" + Variable(strings.TrimSpace(offset)) + ")"
+}
+
+// CurrentUser is the primary key of the user running the query.
+func CurrentUser() Variable {
+	return varCurrentUser
+}
+
+// CurrentRole is the primary key of the role of the user running the query.
+func CurrentRole() Variable {
+	return varCurrentRole
+}
+
+var nowOffset = regexp.MustCompile(`^\$NOW\([+-]?\s*\d+\s*(year|month|week|day|hour|minute|second)s?\)$`)
+
+// Valid reports whether v is a variable Directus knows, with a well-formed
+// offset for $NOW.
+func (v Variable) Valid() bool {
+	switch v {
+	case varNow, varCurrentUser, varCurrentRole:
+		return true
+	}
+	return nowOffset.MatchString(string(v))
+}
@@ -0,0 +1,31 @@
+package directus_client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariables(t *testing.T) {
+	q := DirectusQuery{Filter: Filter{
+		"date_created": {OP_gte: NowOffset(" -1 week ")},
+		"date_updated": {OP_lte: Now()},
+		"user_created": {OP_eq: CurrentUser()},
+		"role":         {OP_neq: CurrentRole()},
+	}}
+	v, err := q.BuildQuery()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"date_created": {"_gte": "$NOW(-1 week)"},
+		"date_updated": {"_lte": "$NOW"},
+		"user_created": {"_eq": "$CURRENT_USER"},
+		"role": {"_neq": "$CURRENT_ROLE"}
+	}`, v.Get("filter"))
+
+	for _, valid := range []Variable{Now(), CurrentUser(), CurrentRole(), NowOffset("-1 week"), NowOffset("+2 hours"), NowOffset("30 minutes")} {
+		require.True(t, valid.Valid(), valid)
+	}
+	for _, invalid := range []Variable{"$NOW(yesterday)", "$NOW(-1 fortnight)", "$CURRENT_TEAM", NowOffset("")} {
+		require.False(t, invalid.Valid(), invalid)
+	}
+}
@@ -0,0 +1,76 @@
+package directus_client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// validVersion accepts content version keys such as "draft" and version ids.
+func validVersion(s string) bool {
+	if s == "" || len(s) > QUERY_MAX_FIELD_LENGTH {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (d *DirectusQuery) validateVersion() error {
+	if d.Version != "" && !validVersion(d.Version) {
+		return errors.New("invalid version: " + strconv.Quote(d.Version))
+	}
+	if d.VersionRaw && d.Version == "" {
+		return errors.New("versionRaw requires a version")
+	}
+	return nil
+}
+
+// buildVersion sets the version and versionRaw parameters of d.
+func (d *DirectusQuery) buildVersion(v url.Values) {
+	if d.Version != "" {
+		v.Set("version", d.Version)
+	}
+	if d.VersionRaw {
+		v.Set("versionRaw", "true")
+	}
+}
+
+// parseVersion reads the version and versionRaw parameters of q into d.
+func parseVersion(q url.Values, d *DirectusQuery) error {
+	d.Version = q.Get("version")
+	if raw := q.Get("versionRaw"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("versionRaw must be true or false")
+		}
+		d.VersionRaw = b
+	}
+	return nil
+}
+
+// GetVersion returns the item with primary key id as of the content version
+// with key version, e.g. "draft", with its changes applied unless raw is
+// set, in which case only the changes are returned. Versions are cached
+// apart from the published item.
+func (c *ItemsClient[T]) GetVersion(ctx context.Context, id any, version string, raw bool) (T, error) {
+	var item T
+	q := DirectusQuery{Version: version, VersionRaw: raw}
+	if err := q.validateVersion(); err != nil {
+		return item, err
+	}
+	path, err := c.itemPath(id)
+	if err != nil {
+		return item, err
+	}
+	v := url.Values{}
+	q.buildVersion(v)
+	err = c.send(ctx, "GET", path, v, nil, &item)
+	return item, err
+}
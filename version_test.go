@@ -0,0 +1,64 @@
+package directus_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion(t *testing.T) {
+	query := DirectusQuery{Filter: Filter{}, Version: "draft", VersionRaw: true}
+	v, err := query.BuildQuery()
+	require.NoError(t, err)
+	require.Equal(t, "draft", v.Get("version"))
+	require.Equal(t, "true", v.Get("versionRaw"))
+	parsed, err := ParseQuery(v)
+	require.NoError(t, err)
+	require.Equal(t, "draft", parsed.Version)
+	require.True(t, parsed.VersionRaw)
+
+	for _, bad := range []url.Values{
+		{"version": {"a b"}},
+		{"versionRaw": {"true"}},
+		{"version": {"draft"}, "versionRaw": {"yes"}},
+	} {
+		_, err := ParseQuery(bad)
+		require.Error(t, err, bad.Encode())
+	}
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		title := "published"
+		if v := r.URL.Query().Get("version"); v != "" {
+			title = v
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":1,"title":"` + title + `"}}`))
+	}))
+	defer srv.Close()
+	cache, err := NewRefreshableQueryCache(&memoryCacheService{m: make(map[string][]byte)}, &WebhookEventServer{observes: make(map[string]func(context.Context, WebhookEvent))})
+	require.NoError(t, err)
+	client, err := NewDirectusClient(srv.URL, "token", cache)
+	require.NoError(t, err)
+	articles := Items[article](client, "articles")
+	ctx := context.Background()
+
+	// drafts don't leak into the cached published item, nor the other way
+	for i := 0; i < 2; i++ {
+		a, err := articles.Get(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, "published", a.Title)
+		a, err = articles.GetVersion(ctx, 1, "draft", false)
+		require.NoError(t, err)
+		require.Equal(t, "draft", a.Title)
+	}
+	require.Equal(t, 2, requests)
+
+	_, err = articles.GetVersion(ctx, 1, "", true)
+	require.EqualError(t, err, "versionRaw requires a version")
+}
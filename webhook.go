@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"github.com/rs/zerolog/log"
 	"net"
 	"net/http"
 	"sync"
@@ -19,16 +18,47 @@ type WebhookEvent struct {
 	Collection string          `json:"collection"`
 }
 
+// WebhookEventServer is safe for concurrent use. Observers are called from a
+// single goroutine, one batch of events at a time.
 type WebhookEventServer struct {
-	mu  sync.RWMutex
-	svr *http.Server
+	mu     sync.RWMutex
+	svr    *http.Server
+	listen net.Listener
+	log    Logger
+	// nil unless WithWebhookMetrics is used
+	metrics *Metrics
 	// map of collection name to function
-	observes map[string]func(WebhookEvent)
+	observes map[string]func(context.Context, WebhookEvent)
+
+	// passed to observers, cancelled by Shutdown
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+	stopped  chan struct{}
 }
 
-func NewWebhookEventServer(addr string, path string) (*WebhookEventServer, error) {
+// WebhookOption configures a WebhookEventServer.
+type WebhookOption func(*WebhookEventServer)
+
+// WithWebhookLogger sets the logger of the server. Nothing is logged by
+// default.
+func WithWebhookLogger(l Logger) WebhookOption {
+	return func(s *WebhookEventServer) {
+		s.log = l
+	}
+}
+
+func NewWebhookEventServer(addr string, path string, opts ...WebhookOption) (*WebhookEventServer, error) {
 	s := &WebhookEventServer{
-		observes: make(map[string]func(WebhookEvent)),
+		observes: make(map[string]func(context.Context, WebhookEvent)),
+		log:      nopLogger{},
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	for _, opt := range opts {
+		opt(s)
 	}
 	if err := s.serve(addr, path); err != nil {
 		return nil, err
@@ -37,6 +67,14 @@ func NewWebhookEventServer(addr string, path string) (*WebhookEventServer, error
 }
 
 func (wes *WebhookEventServer) AddObserver(collection string, f func(WebhookEvent)) error {
+	return wes.AddObserverContext(collection, func(_ context.Context, we WebhookEvent) {
+		f(we)
+	})
+}
+
+// AddObserverContext is like AddObserver, but f receives a context that is
+// cancelled once Shutdown is called.
+func (wes *WebhookEventServer) AddObserverContext(collection string, f func(context.Context, WebhookEvent)) error {
 	wes.mu.Lock()
 	defer wes.mu.Unlock()
 	if _, ok := wes.observes[collection]; ok {
@@ -46,7 +84,8 @@ func (wes *WebhookEventServer) AddObserver(collection string, f func(WebhookEven
 	return nil
 }
 func (wes *WebhookEventServer) RemoveObserver(collection string) {
-	wes.mu.RLock()
+	wes.mu.Lock()
+	defer wes.mu.Unlock()
 	delete(wes.observes, collection)
 }
 
@@ -54,10 +93,11 @@ func (wes *WebhookEventServer) serve(addr string, path string) error {
 	mux := http.NewServeMux()
 
 	// NOTE begin directus bug, should be fixed in next release
-	done := make(chan struct{})
+	done := wes.done
 
 	fluxInput, fluxOutput := makeTimedBufferTransferChan[*WebhookEvent](time.Second, done)
 	go func() {
+		defer close(wes.stopped)
 		for {
 			select {
 			case <-done:
@@ -67,16 +107,20 @@ func (wes *WebhookEventServer) serve(addr string, path string) error {
 				for _, e := range ex {
 					uniqueEvents[e.Collection+":"+e.Event+":"+e.Key] = e
 				}
-				wes.mu.RLock()
 				for _, e := range uniqueEvents {
-					if f, ok := wes.observes[e.Collection]; ok {
-						f(*e)
+					wes.metrics.observeWebhookEvent(e)
+					// observers run without the lock held, so they may add or
+					// remove observers themselves
+					wes.mu.RLock()
+					f, all := wes.observes[e.Collection], wes.observes["*"]
+					wes.mu.RUnlock()
+					if f != nil {
+						f(wes.ctx, *e)
 					}
-					if f, ok := wes.observes["*"]; ok {
-						f(*e)
+					if all != nil {
+						all(wes.ctx, *e)
 					}
 				}
-				wes.mu.RUnlock()
 			}
 		}
 	}()
@@ -95,12 +139,17 @@ func (wes *WebhookEventServer) serve(addr string, path string) error {
 
 		we := new(WebhookEvent)
 
-		if err := json.NewDecoder(r.Body).Decode(we); err != nil {
+		if err := decodeJSON(r.Body, we); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
-		fluxInput <- we
+		select {
+		case fluxInput <- we:
+		case <-done:
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -110,16 +159,27 @@ func (wes *WebhookEventServer) serve(addr string, path string) error {
 	if err != nil {
 		return err
 	}
-	log.Logger.Info().Msg("webhook server listening on " + addr + path)
+	wes.log.Info("webhook server listening on " + listen.Addr().String() + path)
 
-	wes.svr.RegisterOnShutdown(func() {
-		done <- struct{}{}
-	})
+	wes.listen = listen
 	go wes.svr.Serve(listen)
 
 	return nil
 }
+
+// Addr returns the address the server listens on, e.g. to find the port
+// picked for ":0".
+func (wes *WebhookEventServer) Addr() net.Addr {
+	return wes.listen.Addr()
+}
+
+// Shutdown stops the server. Once it returns no observer is called anymore.
 func (wes *WebhookEventServer) Shutdown() error {
+	wes.stopOnce.Do(func() {
+		wes.cancel()
+		close(wes.done)
+	})
+	<-wes.stopped
 	return wes.svr.Shutdown(context.Background())
 }
 
@@ -127,22 +187,26 @@ func makeTimedBufferTransferChan[T any](duration time.Duration, done <-chan stru
 	inChan := make(chan T, 4)
 	outChan := make(chan []T, 4)
 	go func() {
+		ticker := time.NewTicker(duration)
+		defer ticker.Stop()
 		buf := make([]T, 0, 2)
 		for {
 			select {
 			case <-done:
-				close(inChan)
-				close(outChan)
 				return
 			case e := <-inChan:
 				buf = append(buf, e)
-			case <-time.Tick(duration):
+			case <-ticker.C:
 				if len(buf) > 0 {
-					outChan <- buf
+					select {
+					case outChan <- buf:
+					case <-done:
+						return
+					}
 					buf = make([]T, 0, 2)
 				}
 			}
 		}
 	}()
 	return inChan, outChan
-}
\ No newline at end of file
+}
@@ -14,6 +14,7 @@ func TestWebhook(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	defer wes.Shutdown()
 
 	wes.AddObserver("*", func(we WebhookEvent) {
 		data, _ := json.MarshalIndent(&we, "", "  ")
@@ -31,4 +32,4 @@ func TestWebhook(t *testing.T) {
         }`)
 		http.Post("http://localhost:8080/webhook", "application/json", io.NopCloser(bytes.NewReader(payload)))
 	}
-}
\ No newline at end of file
+}